@@ -0,0 +1,297 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dogstatsd
+
+import (
+	"math/rand"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/adapters"
+	"github.com/uber/jaeger-lib/metrics/statsd"
+)
+
+// random decides which updates are sent when sampling, replaced in tests.
+var random = rand.Float64
+
+// Factory implements metrics.Factory by sending DogStatsD lines over UDP or a Unix socket.
+// Unlike plain StatsD, tags are sent natively, e.g. "requests:1|c|#result:ok",
+// and Timers and Histograms are sent as distributions.
+type Factory struct {
+	metrics.Factory
+	sender *statsd.Sender
+}
+
+type options struct {
+	prefix        string
+	tags          map[string]string
+	sampleRate    float64
+	maxPacketSize int
+	flushInterval time.Duration
+	scopeSep      string
+	errorHandler  func(error)
+}
+
+// Option is a function that sets some option for the Factory constructor.
+type Option func(*options)
+
+// WithPrefix returns an option that sets a prefix prepended to all metric names,
+// e.g. "jaeger.". The prefix is used verbatim, without a separator.
+func WithPrefix(prefix string) Option {
+	return func(opts *options) {
+		opts.prefix = prefix
+	}
+}
+
+// WithTags returns an option that sets constant tags added to all metrics,
+// e.g. the environment or the service version. Tags of the metrics and
+// their namespaces take precedence over the constant tags.
+func WithTags(tags map[string]string) Option {
+	return func(opts *options) {
+		opts.tags = tags
+	}
+}
+
+// WithSampleRate returns an option that sets the fraction, between 0 and 1, of the
+// Counter, Timer and Histogram updates that are sent. The rate is sent along with
+// the sampled values so that the agent can scale them back. Gauges are never sampled.
+// If not used, we fallback to 1, i.e. all updates are sent.
+func WithSampleRate(rate float64) Option {
+	return func(opts *options) {
+		opts.sampleRate = rate
+	}
+}
+
+// WithMaxPacketSize returns an option that sets the maximum size of a packet.
+// If not used, we fallback to statsd.DefaultUDPPacketSize for UDP and to
+// statsd.DefaultUnixPacketSize for Unix sockets.
+func WithMaxPacketSize(size int) Option {
+	return func(opts *options) {
+		opts.maxPacketSize = size
+	}
+}
+
+// WithFlushInterval returns an option that sets how often buffered lines are sent
+// when the packet is not full. If not used, we fallback to statsd.DefaultFlushInterval.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.flushInterval = interval
+	}
+}
+
+// WithScopeSeparator returns an option that sets the separator between namespace
+// names and metric names. If not used, we fallback to ".".
+func WithScopeSeparator(separator string) Option {
+	return func(opts *options) {
+		opts.scopeSep = separator
+	}
+}
+
+// WithErrorHandler returns an option that sets a function called with errors
+// writing packets. If not used, such errors are dropped.
+func WithErrorHandler(handler func(error)) Option {
+	return func(opts *options) {
+		opts.errorHandler = handler
+	}
+}
+
+func applyOptions(network string, opts []Option) *options {
+	options := &options{
+		sampleRate:    1,
+		flushInterval: statsd.DefaultFlushInterval,
+	}
+	for _, o := range opts {
+		o(options)
+	}
+	if options.maxPacketSize <= 0 {
+		options.maxPacketSize = statsd.DefaultPacketSize(network)
+	}
+	if options.sampleRate <= 0 || options.sampleRate > 1 {
+		options.sampleRate = 1
+	}
+	return options
+}
+
+// New creates a Factory sending metrics to a DogStatsD agent at address, where network
+// is "udp", "udp4", "udp6" or "unixgram", as accepted by net.Dial.
+// Close must be called to send the buffered metrics and release the connection.
+func New(network, address string, opts ...Option) (*Factory, error) {
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+	options := applyOptions(network, opts)
+	sender := statsd.NewSender(conn, options.maxPacketSize, options.flushInterval, options.errorHandler)
+	return &Factory{
+		Factory: adapters.WrapFactoryWithTags(
+			&factory{
+				prefix:     options.prefix,
+				tags:       options.tags,
+				sampleRate: options.sampleRate,
+				sample:     random,
+				sender:     sender,
+			},
+			adapters.Options{ScopeSep: options.scopeSep},
+		),
+		sender: sender,
+	}, nil
+}
+
+// Flush sends the buffered metrics.
+func (f *Factory) Flush() error {
+	return f.sender.Flush()
+}
+
+// Close sends the buffered metrics and closes the connection.
+func (f *Factory) Close() error {
+	return f.sender.Close()
+}
+
+// factory implements adapters.FactoryWithTags
+type factory struct {
+	prefix     string
+	tags       map[string]string
+	sampleRate float64
+	sample     func() float64
+	sender     *statsd.Sender
+}
+
+func (f *factory) newMetric(name string, tags map[string]string, sampled bool) metric {
+	m := metric{
+		name:   statsd.Sanitize(f.prefix + name),
+		tags:   f.formatTags(tags),
+		sender: f.sender,
+	}
+	if sampled && f.sampleRate < 1 {
+		m.sampleRate = f.sampleRate
+		m.sample = f.sample
+		m.rate = strconv.FormatFloat(f.sampleRate, 'f', -1, 64)
+	}
+	return m
+}
+
+// formatTags merges the metric tags with the constant tags and formats them as
+// "k1:v1,k2:v2", sorted by key so that the same tags always produce the same line.
+func (f *factory) formatTags(tags map[string]string) string {
+	merged := make(map[string]string, len(f.tags)+len(tags))
+	for k, v := range f.tags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = SanitizeTag(strings.Replace(k, ":", "_", -1)) + ":" + SanitizeTag(merged[k])
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (f *factory) Counter(options metrics.Options) metrics.Counter {
+	return &counter{f.newMetric(options.Name, options.Tags, true)}
+}
+
+func (f *factory) Gauge(options metrics.Options) metrics.Gauge {
+	return &gauge{f.newMetric(options.Name, options.Tags, false)}
+}
+
+func (f *factory) Timer(options metrics.TimerOptions) metrics.Timer {
+	// distributions are aggregated by the Datadog backend, buckets are not needed
+	return &timer{f.newMetric(options.Name, options.Tags, true)}
+}
+
+func (f *factory) Histogram(options metrics.HistogramOptions) metrics.Histogram {
+	// distributions are aggregated by the Datadog backend, buckets are not needed
+	return &histogram{f.newMetric(options.Name, options.Tags, true)}
+}
+
+// tagSanitizer replaces the characters that have a meaning in the DogStatsD tags section.
+var tagSanitizer = strings.NewReplacer(",", "_", "|", "_", "#", "_", "\n", "_")
+
+// SanitizeTag replaces the characters that cannot be used in a DogStatsD tag.
+func SanitizeTag(tag string) string {
+	return tagSanitizer.Replace(tag)
+}
+
+type metric struct {
+	name       string
+	tags       string
+	sampleRate float64
+	sample     func() float64
+	rate       string
+	sender     *statsd.Sender
+}
+
+func (m *metric) send(value string, metricType string) {
+	if m.sample != nil && m.sample() >= m.sampleRate {
+		return
+	}
+	line := make([]byte, 0, len(m.name)+len(value)+len(metricType)+len(m.rate)+len(m.tags)+6)
+	line = append(line, m.name...)
+	line = append(line, ':')
+	line = append(line, value...)
+	line = append(line, '|')
+	line = append(line, metricType...)
+	if m.rate != "" {
+		line = append(line, "|@"...)
+		line = append(line, m.rate...)
+	}
+	if m.tags != "" {
+		line = append(line, "|#"...)
+		line = append(line, m.tags...)
+	}
+	m.sender.Send(line)
+}
+
+type counter struct {
+	metric
+}
+
+func (c *counter) Inc(delta int64) {
+	c.send(strconv.FormatInt(delta, 10), "c")
+}
+
+type gauge struct {
+	metric
+}
+
+func (g *gauge) Update(value int64) {
+	g.send(strconv.FormatInt(value, 10), "g")
+}
+
+type timer struct {
+	metric
+}
+
+func (t *timer) Record(d time.Duration) {
+	t.send(statsd.FormatMillis(d), "d")
+}
+
+type histogram struct {
+	metric
+}
+
+func (h *histogram) Record(value float64) {
+	h.send(strconv.FormatFloat(value, 'f', -1, 64), "d")
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dogstatsd
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+var _ metrics.Factory = new(Factory)
+
+func listen(t *testing.T) net.PacketConn {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	return conn
+}
+
+func readLines(t *testing.T, conn net.PacketConn) []string {
+	buf := make([]byte, 65536)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	return strings.Split(string(buf[:n]), "\n")
+}
+
+func TestFactory(t *testing.T) {
+	conn := listen(t)
+	defer conn.Close()
+
+	f, err := New("udp", conn.LocalAddr().String(),
+		WithPrefix("jaeger."),
+		WithTags(map[string]string{"env": "prod", "a": "const"}),
+		WithFlushInterval(0),
+	)
+	require.NoError(t, err)
+
+	ns := f.Namespace(metrics.NSOptions{
+		Name: "query",
+		Tags: map[string]string{"a": "b"},
+	}).Namespace(metrics.NSOptions{
+		Name: "http",
+		Tags: map[string]string{"c": "d"},
+	})
+	ns.Counter(metrics.Options{
+		Name: "requests",
+		Tags: map[string]string{"result": "ok", "c": "override"},
+	}).Inc(3)
+	ns.Gauge(metrics.Options{Name: "queue-length"}).Update(-42)
+	ns.Timer(metrics.TimerOptions{Name: "latency"}).Record(1500 * time.Microsecond)
+	ns.Histogram(metrics.HistogramOptions{Name: "size"}).Record(1.5)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, []string{
+		"jaeger.query.http.requests:3|c|#a:b,c:override,env:prod,result:ok",
+		"jaeger.query.http.queue-length:-42|g|#a:b,c:d,env:prod",
+		"jaeger.query.http.latency:1.5|d|#a:b,c:d,env:prod",
+		"jaeger.query.http.size:1.5|d|#a:b,c:d,env:prod",
+	}, readLines(t, conn))
+}
+
+func TestFactoryNoTags(t *testing.T) {
+	conn := listen(t)
+	defer conn.Close()
+
+	f, err := New("udp", conn.LocalAddr().String(), WithFlushInterval(0), WithScopeSeparator("_"))
+	require.NoError(t, err)
+	f.Namespace(metrics.NSOptions{Name: "ns"}).Counter(metrics.Options{Name: "counter"}).Inc(1)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, []string{"ns_counter:1|c"}, readLines(t, conn))
+}
+
+func TestFactorySampleRate(t *testing.T) {
+	values := []float64{0.1, 0.9, 0.3, 0.2}
+	defer func(r func() float64) { random = r }(random)
+	random = func() float64 {
+		v := values[0]
+		values = values[1:]
+		return v
+	}
+
+	conn := listen(t)
+	defer conn.Close()
+
+	f, err := New("udp", conn.LocalAddr().String(), WithFlushInterval(0), WithSampleRate(0.25))
+	require.NoError(t, err)
+	c := f.Counter(metrics.Options{Name: "counter", Tags: map[string]string{"x": "y"}})
+	c.Inc(1)                                                           // sampled in
+	c.Inc(2)                                                           // sampled out
+	f.Timer(metrics.TimerOptions{Name: "timer"}).Record(time.Second)   // sampled out
+	f.Gauge(metrics.Options{Name: "gauge"}).Update(7)                  // gauges are never sampled
+	f.Histogram(metrics.HistogramOptions{Name: "histogram"}).Record(3) // sampled in
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, []string{
+		"counter:1|c|@0.25|#x:y",
+		"gauge:7|g",
+		"histogram:3|d|@0.25",
+	}, readLines(t, conn))
+	assert.Empty(t, values)
+}
+
+func TestFactorySanitize(t *testing.T) {
+	conn := listen(t)
+	defer conn.Close()
+
+	f, err := New("udp", conn.LocalAddr().String(), WithFlushInterval(0))
+	require.NoError(t, err)
+	f.Counter(metrics.Options{
+		Name: "my|counter",
+		Tags: map[string]string{"k:ey": "a,b|c#d"},
+	}).Inc(1)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, []string{"my_counter:1|c|#k_ey:a_b_c_d"}, readLines(t, conn))
+}
+
+func TestFactoryDialError(t *testing.T) {
+	_, err := New("invalid", "localhost:8125")
+	assert.Error(t, err)
+}
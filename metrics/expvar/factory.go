@@ -23,6 +23,7 @@ import (
 
 // NewFactory creates a new metrics factory using go-kit expvar package.
 // buckets is the number of buckets to be used in histograms.
+// The tags are flattened into the variable names, see NewTaggedFactory to keep them.
 func NewFactory(buckets int) metrics.Factory {
 	return adapters.WrapFactoryWithoutTags(
 		&factory{
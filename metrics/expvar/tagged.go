@@ -0,0 +1,352 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvar
+
+import (
+	"encoding/json"
+	"expvar"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/HdrHistogram/hdrhistogram-go"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/adapters"
+	"github.com/uber/jaeger-lib/metrics/internal/percentile"
+)
+
+var (
+	// DefaultBuckets are the buckets of Histograms created without buckets.
+	DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+	// DefaultTimerBuckets are the buckets of Timers created without buckets.
+	DefaultTimerBuckets = []time.Duration{
+		time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond, 25 * time.Millisecond,
+		50 * time.Millisecond, 100 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond,
+		time.Second, 2500 * time.Millisecond, 5 * time.Second, 10 * time.Second,
+	}
+
+	// DefaultPercentiles are the percentiles reported for Timers and Histograms.
+	DefaultPercentiles = []float64{50, 75, 90, 95, 99, 99.9}
+)
+
+const (
+	// timers are recorded in the HDR histograms in microseconds, up to an hour
+	timerScale   = 1e6
+	timerHDRMax  = int64(time.Hour / time.Microsecond)
+	histogramMax = 1e9
+	// histograms are recorded in the HDR histograms in thousandths, up to histogramMax
+	histogramScale  = 1e3
+	histogramHDRMax = int64(histogramMax * histogramScale)
+	hdrSigFigs      = 2
+)
+
+// The variables are published once per name and shared by all the factories,
+// like the expvar registry they belong to.
+var (
+	variablesLock sync.Mutex
+	variables     = make(map[string]*variable)
+)
+
+type taggedOptions struct {
+	scopeSep    string
+	percentiles []float64
+}
+
+// Option is a function that sets some option for NewTaggedFactory.
+type Option func(*taggedOptions)
+
+// WithScopeSeparator returns an option that sets the separator between namespace
+// names and metric names. If not used, we fallback to ".".
+func WithScopeSeparator(separator string) Option {
+	return func(opts *taggedOptions) {
+		opts.scopeSep = separator
+	}
+}
+
+// WithPercentiles returns an option that sets the percentiles, between 0 and 100,
+// reported for Timers and Histograms. If not used, we fallback to DefaultPercentiles.
+func WithPercentiles(percentiles []float64) Option {
+	return func(opts *taggedOptions) {
+		opts.percentiles = percentiles
+	}
+}
+
+// NewTaggedFactory creates a metrics factory publishing one expvar.Var per metric name,
+// whose value is a JSON object with an entry per set of tags, instead of flattening
+// the tags into the variable names like NewFactory does. For example, a counter
+// "requests" with the tags result=ok and result=err is published as
+//
+//	{"type":"counter","series":[{"tags":{"result":"err"},"value":1},{"tags":{"result":"ok"},"value":7}]}
+//
+// Gauges have the same structure. Timers and Histograms report their declared buckets
+// and, for each set of tags, the count and sum of the values, the number of values in
+// each bucket, i.e. between the previous bound excluded and the bucket bound included,
+// with one more count for the values above the last bound, and percentiles estimated
+// with an HDR histogram. Timers are in seconds. The values are cumulative since the start.
+//
+// The percentiles are accurate to two significant digits, and only account for the values
+// between 0 and an hour for Timers, and between 0 and 1e9 for Histograms.
+//
+// Metrics of different types cannot share a name: a metric created with the name of a metric
+// of another type, or of a variable published by another package, discards its values.
+// The buckets of a name are the ones of the first metric created with it.
+func NewTaggedFactory(opts ...Option) metrics.Factory {
+	options := &taggedOptions{
+		percentiles: DefaultPercentiles,
+	}
+	for _, o := range opts {
+		o(options)
+	}
+	return adapters.WrapFactoryWithTags(
+		&taggedFactory{percentiles: options.percentiles},
+		adapters.Options{ScopeSep: options.scopeSep},
+	)
+}
+
+// taggedFactory implements adapters.FactoryWithTags
+type taggedFactory struct {
+	percentiles []float64
+}
+
+func (f *taggedFactory) Counter(options metrics.Options) metrics.Counter {
+	v := findOrPublish(options.Name, counterType, nil, 0, 0, nil)
+	if v == nil {
+		return metrics.NullCounter
+	}
+	return (*int64Series)(v.findOrCreate(options.Tags))
+}
+
+func (f *taggedFactory) Gauge(options metrics.Options) metrics.Gauge {
+	v := findOrPublish(options.Name, gaugeType, nil, 0, 0, nil)
+	if v == nil {
+		return metrics.NullGauge
+	}
+	return (*int64Series)(v.findOrCreate(options.Tags))
+}
+
+func (f *taggedFactory) Timer(options metrics.TimerOptions) metrics.Timer {
+	buckets := options.Buckets
+	if len(buckets) == 0 {
+		buckets = DefaultTimerBuckets
+	}
+	bounds := make([]float64, len(buckets))
+	for i, b := range buckets {
+		bounds[i] = b.Seconds()
+	}
+	v := findOrPublish(options.Name, timerType, bounds, timerScale, timerHDRMax, f.percentiles)
+	if v == nil {
+		return metrics.NullTimer
+	}
+	return &timer{v.findOrCreate(options.Tags)}
+}
+
+func (f *taggedFactory) Histogram(options metrics.HistogramOptions) metrics.Histogram {
+	bounds := options.Buckets
+	if len(bounds) == 0 {
+		bounds = DefaultBuckets
+	}
+	v := findOrPublish(options.Name, histogramType, bounds, histogramScale, histogramHDRMax, f.percentiles)
+	if v == nil {
+		return metrics.NullHistogram
+	}
+	return &histogram{v.findOrCreate(options.Tags)}
+}
+
+const (
+	counterType   = "counter"
+	gaugeType     = "gauge"
+	timerType     = "timer"
+	histogramType = "histogram"
+)
+
+// variable implements expvar.Var for all the series of a metric name.
+type variable struct {
+	typ         string
+	bounds      []float64
+	scale       float64
+	hdrMax      int64
+	percentiles []float64
+	lock        sync.Mutex
+	series      map[string]*series
+}
+
+// findOrPublish returns the variable of the name, or nil if the name is already used
+// by a variable of another type or by another package.
+func findOrPublish(name, typ string, bounds []float64, scale float64, hdrMax int64, percentiles []float64) *variable {
+	variablesLock.Lock()
+	defer variablesLock.Unlock()
+	if v, ok := variables[name]; ok {
+		if v.typ != typ {
+			return nil
+		}
+		return v
+	}
+	if expvar.Get(name) != nil {
+		return nil
+	}
+	v := &variable{
+		typ:         typ,
+		bounds:      append([]float64(nil), bounds...),
+		scale:       scale,
+		hdrMax:      hdrMax,
+		percentiles: percentiles,
+		series:      make(map[string]*series),
+	}
+	sort.Float64s(v.bounds)
+	expvar.Publish(name, v)
+	variables[name] = v
+	return v
+}
+
+func (v *variable) findOrCreate(tags map[string]string) *series {
+	key := metrics.GetKey("", tags, "|", "=")
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if s, ok := v.series[key]; ok {
+		return s
+	}
+	s := &series{variable: v, tags: make(map[string]string, len(tags))}
+	for k, val := range tags {
+		s.tags[k] = val
+	}
+	if v.isDistribution() {
+		s.counts = make([]int64, len(v.bounds)+1)
+		s.hdr = hdrhistogram.New(0, v.hdrMax, hdrSigFigs)
+	}
+	v.series[key] = s
+	return s
+}
+
+type seriesJSON struct {
+	Tags        map[string]string  `json:"tags"`
+	Value       *int64             `json:"value,omitempty"`
+	Count       *int64             `json:"count,omitempty"`
+	Sum         *float64           `json:"sum,omitempty"`
+	Counts      []int64            `json:"counts,omitempty"`
+	Percentiles map[string]float64 `json:"percentiles,omitempty"`
+}
+
+type variableJSON struct {
+	Type    string       `json:"type"`
+	Buckets []float64    `json:"buckets,omitempty"`
+	Series  []seriesJSON `json:"series"`
+}
+
+func (v *variable) isDistribution() bool {
+	return v.typ == timerType || v.typ == histogramType
+}
+
+// String implements expvar.Var, the series are sorted by tags.
+func (v *variable) String() string {
+	v.lock.Lock()
+	keys := make([]string, 0, len(v.series))
+	for k := range v.series {
+		keys = append(keys, k)
+	}
+	all := make([]*series, 0, len(keys))
+	sort.Strings(keys)
+	for _, k := range keys {
+		all = append(all, v.series[k])
+	}
+	v.lock.Unlock()
+
+	out := variableJSON{Type: v.typ, Buckets: v.bounds, Series: make([]seriesJSON, 0, len(all))}
+	for _, s := range all {
+		out.Series = append(out.Series, s.snapshot())
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		return strconv.Quote(err.Error())
+	}
+	return string(b)
+}
+
+// series holds the values of a metric for a set of tags.
+type series struct {
+	value    int64 // first for 64-bit alignment of atomic operations
+	variable *variable
+	tags     map[string]string
+	lock     sync.Mutex
+	count    int64
+	sum      float64
+	counts   []int64
+	hdr      *hdrhistogram.Histogram
+}
+
+func (s *series) snapshot() seriesJSON {
+	out := seriesJSON{Tags: s.tags}
+	if !s.variable.isDistribution() {
+		value := atomic.LoadInt64(&s.value)
+		out.Value = &value
+		return out
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	count, sum := s.count, s.sum
+	out.Count, out.Sum = &count, &sum
+	out.Counts = append([]int64(nil), s.counts...)
+	if s.hdr.TotalCount() > 0 && len(s.variable.percentiles) > 0 {
+		out.Percentiles = make(map[string]float64, len(s.variable.percentiles))
+		for _, p := range s.variable.percentiles {
+			out.Percentiles[percentile.Name(p)] = float64(s.hdr.ValueAtQuantile(p)) / s.variable.scale
+		}
+	}
+	return out
+}
+
+func (s *series) record(v float64) {
+	// bucket i counts the values in (bounds[i-1], bounds[i]]
+	i := sort.SearchFloat64s(s.variable.bounds, v)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.count++
+	s.sum += v
+	s.counts[i]++
+	if scaled := math.Round(v * s.variable.scale); scaled >= 0 && scaled <= float64(s.variable.hdrMax) {
+		s.hdr.RecordValue(int64(scaled))
+	}
+}
+
+// int64Series implements metrics.Counter and metrics.Gauge.
+type int64Series series
+
+func (s *int64Series) Inc(delta int64) {
+	atomic.AddInt64(&s.value, delta)
+}
+
+func (s *int64Series) Update(value int64) {
+	atomic.StoreInt64(&s.value, value)
+}
+
+type timer struct {
+	*series
+}
+
+func (t *timer) Record(d time.Duration) {
+	t.record(d.Seconds())
+}
+
+type histogram struct {
+	*series
+}
+
+func (h *histogram) Record(v float64) {
+	h.record(v)
+}
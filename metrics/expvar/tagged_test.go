@@ -0,0 +1,114 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expvar
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+func getTagged(t *testing.T, name string) variableJSON {
+	v := expvar.Get(name)
+	require.NotNil(t, v, name)
+	var out variableJSON
+	require.NoError(t, json.Unmarshal([]byte(v.String()), &out))
+	return out
+}
+
+func TestTaggedFactory(t *testing.T) {
+	f := NewTaggedFactory(WithPercentiles([]float64{50, 99.9}))
+	ns := f.Namespace(metrics.NSOptions{Name: prefix + "_tagged", Tags: map[string]string{"a": "b"}})
+
+	ns.Counter(metrics.Options{Name: "requests", Tags: map[string]string{"result": "ok"}}).Inc(7)
+	ns.Counter(metrics.Options{Name: "requests", Tags: map[string]string{"result": "err"}}).Inc(1)
+	ns.Gauge(metrics.Options{Name: "queue"}).Update(42)
+	timer := ns.Timer(metrics.TimerOptions{Name: "latency", Buckets: []time.Duration{time.Millisecond, time.Second}})
+	timer.Record(500 * time.Microsecond)
+	timer.Record(20 * time.Millisecond)
+	timer.Record(2 * time.Second)
+	histogram := ns.Histogram(metrics.HistogramOptions{Name: "size", Buckets: []float64{10, 1}})
+	histogram.Record(1)
+	histogram.Record(5.5)
+
+	assert.Equal(t,
+		`{"type":"counter","series":[{"tags":{"a":"b","result":"err"},"value":1},{"tags":{"a":"b","result":"ok"},"value":7}]}`,
+		expvar.Get(prefix+"_tagged.requests").String())
+	assert.Equal(t,
+		`{"type":"gauge","series":[{"tags":{"a":"b"},"value":42}]}`,
+		expvar.Get(prefix+"_tagged.queue").String())
+
+	latency := getTagged(t, prefix+"_tagged.latency")
+	assert.Equal(t, timerType, latency.Type)
+	assert.Equal(t, []float64{0.001, 1}, latency.Buckets)
+	require.Len(t, latency.Series, 1)
+	assert.EqualValues(t, 3, *latency.Series[0].Count)
+	assert.InDelta(t, 2.0205, *latency.Series[0].Sum, 1e-9)
+	assert.Equal(t, []int64{1, 1, 1}, latency.Series[0].Counts)
+	assert.InEpsilon(t, 0.02, latency.Series[0].Percentiles["p50"], 0.01)
+	assert.InEpsilon(t, 2, latency.Series[0].Percentiles["p99_9"], 0.01)
+
+	size := getTagged(t, prefix+"_tagged.size")
+	assert.Equal(t, histogramType, size.Type)
+	assert.Equal(t, []float64{1, 10}, size.Buckets, "buckets are sorted")
+	assert.Equal(t, []int64{1, 1, 0}, size.Series[0].Counts, "bounds are inclusive")
+	assert.InEpsilon(t, 5.5, size.Series[0].Percentiles["p99_9"], 0.01)
+}
+
+func TestTaggedFactorySharedNames(t *testing.T) {
+	name := prefix + "_shared"
+	NewTaggedFactory().Counter(metrics.Options{Name: name, Tags: map[string]string{"x": "1"}}).Inc(1)
+	NewTaggedFactory().Counter(metrics.Options{Name: name, Tags: map[string]string{"x": "1"}}).Inc(2)
+	NewTaggedFactory().Counter(metrics.Options{Name: name, Tags: map[string]string{"x": "2"}}).Inc(4)
+	assert.Equal(t,
+		`{"type":"counter","series":[{"tags":{"x":"1"},"value":3},{"tags":{"x":"2"},"value":4}]}`,
+		expvar.Get(name).String())
+
+	assert.NotPanics(t, func() {
+		NewTaggedFactory().Gauge(metrics.Options{Name: name}).Update(10)
+		NewTaggedFactory().Histogram(metrics.HistogramOptions{Name: name}).Record(10)
+	})
+	assert.Equal(t,
+		`{"type":"counter","series":[{"tags":{"x":"1"},"value":3},{"tags":{"x":"2"},"value":4}]}`,
+		expvar.Get(name).String(), "metrics of another type are discarded")
+
+	expvar.NewString(prefix + "_other")
+	assert.NotPanics(t, func() {
+		NewTaggedFactory().Counter(metrics.Options{Name: prefix + "_other"}).Inc(1)
+	})
+}
+
+func TestTaggedFactoryDefaultBuckets(t *testing.T) {
+	f := NewTaggedFactory(WithScopeSeparator("_"))
+	ns := f.Namespace(metrics.NSOptions{Name: prefix + "_defaults"})
+	ns.Timer(metrics.TimerOptions{Name: "timer"}).Record(time.Hour + time.Second)
+	ns.Histogram(metrics.HistogramOptions{Name: "histogram"}).Record(-1)
+
+	timer := getTagged(t, prefix+"_defaults_timer")
+	assert.Len(t, timer.Buckets, len(DefaultTimerBuckets))
+	assert.EqualValues(t, 1, timer.Series[0].Counts[len(DefaultTimerBuckets)])
+	assert.Empty(t, timer.Series[0].Percentiles, "out of the range of the HDR histogram")
+
+	histogram := getTagged(t, prefix+"_defaults_histogram")
+	assert.Equal(t, DefaultBuckets, histogram.Buckets)
+	assert.EqualValues(t, 1, histogram.Series[0].Counts[0])
+	assert.Empty(t, histogram.Series[0].Percentiles)
+}
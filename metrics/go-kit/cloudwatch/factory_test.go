@@ -0,0 +1,109 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudwatch
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/go-kit/kit/log"
+	kitcw "github.com/go-kit/kit/metrics/cloudwatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/go-kit"
+)
+
+// stubCloudWatch records the data of PutMetricData, the other methods are not implemented.
+type stubCloudWatch struct {
+	cloudwatchiface.CloudWatchAPI
+	sync.Mutex
+	namespaces []string
+	values     map[string]float64
+	dimensions map[string]map[string]string
+}
+
+func newStubCloudWatch() *stubCloudWatch {
+	return &stubCloudWatch{
+		values:     make(map[string]float64),
+		dimensions: make(map[string]map[string]string),
+	}
+}
+
+func (s *stubCloudWatch) PutMetricData(input *cloudwatch.PutMetricDataInput) (*cloudwatch.PutMetricDataOutput, error) {
+	s.Lock()
+	defer s.Unlock()
+	s.namespaces = append(s.namespaces, *input.Namespace)
+	for _, datum := range input.MetricData {
+		s.values[*datum.MetricName] = *datum.Value
+		dimensions := make(map[string]string, len(datum.Dimensions))
+		for _, d := range datum.Dimensions {
+			dimensions[*d.Name] = *d.Value
+		}
+		s.dimensions[*datum.MetricName] = dimensions
+	}
+	return &cloudwatch.PutMetricDataOutput{}, nil
+}
+
+func (s *stubCloudWatch) value(name string) (float64, bool) {
+	s.Lock()
+	defer s.Unlock()
+	v, ok := s.values[name]
+	return v, ok
+}
+
+func TestFactory(t *testing.T) {
+	svc := newStubCloudWatch()
+	client := kitcw.New("jaeger", svc, kitcw.WithLogger(log.NewNopLogger()), kitcw.WithPercentiles(0.5, 0.99))
+	cf := NewFactory(client)
+	assert.True(t, cf.Capabilities().Tagging)
+	wf := xkit.Wrap("namespace", cf)
+
+	wf.Counter(metrics.Options{Name: "counter", Tags: map[string]string{"x": "y"}}).Inc(7)
+	wf.Gauge(metrics.Options{Name: "gauge"}).Update(42)
+	wf.Timer(metrics.TimerOptions{Name: "timer", Tags: map[string]string{"x": "z"}}).Record(1500 * time.Millisecond)
+
+	require.NoError(t, client.Send())
+	assert.Equal(t, []string{"jaeger"}, svc.namespaces)
+	assert.Equal(t, map[string]float64{
+		"namespace.counter":  7,
+		"namespace.gauge":    42,
+		"namespace.timer_50": 1.5,
+		"namespace.timer_99": 1.5,
+	}, svc.values)
+	assert.Equal(t, map[string]string{"x": "y"}, svc.dimensions["namespace.counter"])
+	assert.Equal(t, map[string]string{"x": "z"}, svc.dimensions["namespace.timer_99"])
+}
+
+func TestStartWriteLoop(t *testing.T) {
+	svc := newStubCloudWatch()
+	client := kitcw.New("jaeger", svc, kitcw.WithLogger(log.NewNopLogger()))
+	NewFactory(client).Counter("counter").Add(1)
+
+	stop := StartWriteLoop(client, time.Millisecond)
+	for i := 0; i < 1000; i++ {
+		if _, ok := svc.value("counter"); ok {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	stop()
+	v, _ := svc.value("counter")
+	assert.EqualValues(t, 1, v)
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dogstatsd
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics/dogstatsd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/go-kit"
+)
+
+func TestFactory(t *testing.T) {
+	client := dogstatsd.New("prefix.", log.NewNopLogger(), "env", "test")
+	df := NewFactory(client, 1)
+	assert.True(t, df.Capabilities().Tagging)
+	wf := xkit.Wrap("namespace", df)
+
+	wf.Counter(metrics.Options{Name: "counter", Tags: map[string]string{"x": "y"}}).Inc(7)
+	wf.Gauge(metrics.Options{Name: "gauge", Tags: map[string]string{"x": "z"}}).Update(42)
+	wf.Timer(metrics.TimerOptions{Name: "timer"}).Record(1500 * time.Millisecond)
+	wf.Histogram(metrics.HistogramOptions{Name: "histogram"}).Record(3)
+
+	var buf bytes.Buffer
+	_, err := client.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"prefix.namespace.counter:7.000000|c|#env:test,x:y",
+		"prefix.namespace.gauge:42.000000|g|#env:test,x:z",
+		"prefix.namespace.timer:1.500000|h|#env:test",
+		"prefix.namespace.histogram:3.000000|h|#env:test",
+	}, strings.Split(strings.TrimSpace(buf.String()), "\n"))
+}
+
+func TestStartWriteLoop(t *testing.T) {
+	client := dogstatsd.New("", log.NewNopLogger())
+	NewFactory(client, 1).Counter("counter").With("x", "y").Add(1)
+
+	w := &lockedBuffer{}
+	stop := StartWriteLoop(client, time.Millisecond, w)
+	for i := 0; i < 1000 && w.String() == ""; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	stop()
+	assert.Equal(t, "counter:1.000000|c|#x:y\n", w.String())
+}
+
+func TestStartSendLoop(t *testing.T) {
+	client := dogstatsd.New("", log.NewNopLogger())
+	stop := StartSendLoop(client, time.Millisecond, "udp", "127.0.0.1:0")
+	stop()
+}
+
+type lockedBuffer struct {
+	sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.String()
+}
@@ -15,6 +15,8 @@
 package xkit
 
 import (
+	"time"
+
 	kit "github.com/go-kit/kit/metrics"
 
 	"github.com/uber/jaeger-lib/metrics"
@@ -33,6 +35,9 @@ type Factory interface {
 type Capabilities struct {
 	// Tagging indicates whether the factory has the capability for tagged metrics
 	Tagging bool
+	// TimerUnit is the unit the Timers are observed in, e.g. time.Millisecond for the
+	// StatsD timings. If zero, we fallback to seconds.
+	TimerUnit time.Duration
 }
 
 // FactoryOption is a function that adjusts some parameters of the factory.
@@ -118,7 +123,9 @@ func (f *factory) Timer(options metrics.TimerOptions) metrics.Timer {
 	if len(tagsList) > 0 {
 		hist = hist.With(tagsList...)
 	}
-	return NewTimer(hist)
+	timer := NewTimer(hist)
+	timer.unit = f.factory.Capabilities().TimerUnit
+	return timer
 }
 
 func (f *factory) Gauge(options metrics.Options) metrics.Gauge {
@@ -0,0 +1,121 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import (
+	"sync"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/generic"
+
+	"github.com/uber/jaeger-lib/metrics/go-kit"
+)
+
+// Factory is a metrics factory using go-kit generic package, which keeps the metrics
+// in memory, e.g. to read them in tests or to report them from the application.
+// The go-kit generic metrics do not aggregate the values by label, so the tags are
+// flattened into the names and each metric can be read by its name.
+type Factory struct {
+	buckets    int
+	lock       sync.Mutex
+	counters   map[string]*generic.Counter
+	gauges     map[string]*generic.Gauge
+	histograms map[string]*generic.Histogram
+}
+
+// NewFactory creates a new metrics factory using go-kit generic package.
+// buckets is the number of buckets to be used in histograms.
+func NewFactory(buckets int) *Factory {
+	return &Factory{
+		buckets:    buckets,
+		counters:   make(map[string]*generic.Counter),
+		gauges:     make(map[string]*generic.Gauge),
+		histograms: make(map[string]*generic.Histogram),
+	}
+}
+
+// Counter returns the counter with the given name, created on first use.
+func (f *Factory) Counter(name string) metrics.Counter {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	c, ok := f.counters[name]
+	if !ok {
+		c = generic.NewCounter(name)
+		f.counters[name] = c
+	}
+	return c
+}
+
+// Histogram returns the histogram with the given name, created on first use.
+func (f *Factory) Histogram(name string) metrics.Histogram {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	h, ok := f.histograms[name]
+	if !ok {
+		h = generic.NewHistogram(name, f.buckets)
+		f.histograms[name] = h
+	}
+	return h
+}
+
+// Gauge returns the gauge with the given name, created on first use.
+func (f *Factory) Gauge(name string) metrics.Gauge {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	g, ok := f.gauges[name]
+	if !ok {
+		g = generic.NewGauge(name)
+		f.gauges[name] = g
+	}
+	return g
+}
+
+// Capabilities implements xkit.Factory.
+func (f *Factory) Capabilities() xkit.Capabilities {
+	return xkit.Capabilities{Tagging: false}
+}
+
+// Counters returns the counters created so far, by name.
+func (f *Factory) Counters() map[string]*generic.Counter {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	counters := make(map[string]*generic.Counter, len(f.counters))
+	for name, c := range f.counters {
+		counters[name] = c
+	}
+	return counters
+}
+
+// Gauges returns the gauges created so far, by name.
+func (f *Factory) Gauges() map[string]*generic.Gauge {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	gauges := make(map[string]*generic.Gauge, len(f.gauges))
+	for name, g := range f.gauges {
+		gauges[name] = g
+	}
+	return gauges
+}
+
+// Histograms returns the histograms created so far, by name.
+func (f *Factory) Histograms() map[string]*generic.Histogram {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	histograms := make(map[string]*generic.Histogram, len(f.histograms))
+	for name, h := range f.histograms {
+		histograms[name] = h
+	}
+	return histograms
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/go-kit"
+)
+
+var _ xkit.Factory = new(Factory)
+
+func TestFactory(t *testing.T) {
+	gf := NewFactory(10)
+	assert.False(t, gf.Capabilities().Tagging)
+	wf := xkit.Wrap("namespace", gf)
+
+	wf.Counter(metrics.Options{Name: "counter", Tags: map[string]string{"x": "y"}}).Inc(7)
+	wf.Counter(metrics.Options{Name: "counter", Tags: map[string]string{"x": "y"}}).Inc(3)
+	wf.Gauge(metrics.Options{Name: "gauge"}).Update(42)
+	timer := wf.Timer(metrics.TimerOptions{Name: "timer"})
+	timer.Record(time.Second)
+	timer.Record(3 * time.Second)
+	wf.Histogram(metrics.HistogramOptions{Name: "histogram"}).Record(5)
+
+	counters := gf.Counters()
+	require.Contains(t, counters, "namespace.counter.x_y")
+	assert.EqualValues(t, 10, counters["namespace.counter.x_y"].Value())
+	assert.EqualValues(t, 42, gf.Gauges()["namespace.gauge"].Value())
+	histograms := gf.Histograms()
+	require.Len(t, histograms, 2)
+	assert.EqualValues(t, 3, histograms["namespace.timer"].Quantile(0.99))
+	assert.EqualValues(t, 5, histograms["namespace.histogram"].Quantile(0.5))
+}
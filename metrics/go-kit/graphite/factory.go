@@ -0,0 +1,68 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphite
+
+import (
+	"io"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/graphite"
+
+	"github.com/uber/jaeger-lib/metrics/go-kit"
+)
+
+// NewFactory creates a new metrics factory using go-kit graphite package.
+// buckets is the number of buckets to be used in histograms.
+// Graphite has no tags, so the tags are flattened into the paths.
+func NewFactory(client *graphite.Graphite, buckets int) xkit.Factory {
+	return factory{
+		client:  client,
+		buckets: buckets,
+	}
+}
+
+type factory struct {
+	client  *graphite.Graphite
+	buckets int
+}
+
+func (f factory) Counter(name string) metrics.Counter {
+	return f.client.NewCounter(name)
+}
+
+func (f factory) Histogram(name string) metrics.Histogram {
+	return f.client.NewHistogram(name, f.buckets)
+}
+
+func (f factory) Gauge(name string) metrics.Gauge {
+	return f.client.NewGauge(name)
+}
+
+func (f factory) Capabilities() xkit.Capabilities {
+	return xkit.Capabilities{Tagging: false}
+}
+
+// StartSendLoop starts sending the metrics to a Graphite server every interval,
+// and returns a function stopping it, e.g. to pass to provider.NewGraphiteProvider.
+func StartSendLoop(client *graphite.Graphite, interval time.Duration, network, address string) (stop func()) {
+	return xkit.StartSendLoop(client, interval, network, address)
+}
+
+// StartWriteLoop starts writing the metrics to w every interval,
+// and returns a function stopping it.
+func StartWriteLoop(client *graphite.Graphite, interval time.Duration, w io.Writer) (stop func()) {
+	return xkit.StartWriteLoop(client, interval, w)
+}
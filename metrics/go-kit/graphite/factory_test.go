@@ -0,0 +1,90 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphite
+
+import (
+	"bytes"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics/graphite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/go-kit"
+)
+
+// timestamps removes the timestamps at the end of the lines.
+var timestamps = regexp.MustCompile(` \d+\n`)
+
+func TestFactory(t *testing.T) {
+	client := graphite.New("prefix.", log.NewNopLogger())
+	gf := NewFactory(client, 10)
+	assert.False(t, gf.Capabilities().Tagging)
+	wf := xkit.Wrap("namespace", gf)
+
+	wf.Counter(metrics.Options{Name: "counter", Tags: map[string]string{"x": "y"}}).Inc(7)
+	wf.Gauge(metrics.Options{Name: "gauge"}).Update(42)
+	wf.Timer(metrics.TimerOptions{Name: "timer"}).Record(1500 * time.Millisecond)
+
+	var buf bytes.Buffer
+	_, err := client.WriteTo(&buf)
+	require.NoError(t, err)
+	out := timestamps.ReplaceAllString(buf.String(), "\n")
+	assert.Contains(t, out, "prefix.namespace.counter.x_y 7.000000\n")
+	assert.Contains(t, out, "prefix.namespace.gauge 42.000000\n")
+	assert.Contains(t, out, "prefix.namespace.timer.p50 1.500000\n")
+	assert.Contains(t, out, "prefix.namespace.timer.p99 1.500000\n")
+}
+
+func TestStartWriteLoop(t *testing.T) {
+	client := graphite.New("", log.NewNopLogger())
+	NewFactory(client, 10).Gauge("gauge").Set(1)
+
+	w := &lockedBuffer{}
+	stop := StartWriteLoop(client, time.Millisecond, w)
+	for i := 0; i < 1000 && w.String() == ""; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	stop()
+	assert.Regexp(t, `^gauge 1.000000 \d+\n`, w.String())
+}
+
+func TestStartSendLoop(t *testing.T) {
+	client := graphite.New("", log.NewNopLogger())
+	stop := StartSendLoop(client, time.Millisecond, "udp", "127.0.0.1:0")
+	stop()
+}
+
+type lockedBuffer struct {
+	sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.Lock()
+	defer b.Unlock()
+	return b.buf.String()
+}
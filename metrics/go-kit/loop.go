@@ -0,0 +1,70 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xkit
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// Loop is the signature of the send loops of the go-kit providers once bound to
+// their destination, e.g.
+//
+//	func(ctx context.Context, c <-chan time.Time) { client.SendLoop(ctx, c, "udp", address) }
+type Loop func(ctx context.Context, c <-chan time.Time)
+
+// StartLoop runs loop in a goroutine, ticking every interval, and returns a function
+// that stops the loop and waits for it to return. The stop function can be called
+// several times, and can be passed to the constructors of the go-kit provider package.
+func StartLoop(interval time.Duration, loop Loop) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		loop(ctx, ticker.C)
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			cancel()
+			ticker.Stop()
+			<-done
+		})
+	}
+}
+
+// Sender is implemented by the go-kit clients sending their metrics in loops,
+// e.g. statsd.Statsd, dogstatsd.Dogstatsd and graphite.Graphite.
+type Sender interface {
+	SendLoop(ctx context.Context, c <-chan time.Time, network, address string)
+	WriteLoop(ctx context.Context, c <-chan time.Time, w io.Writer)
+}
+
+// StartSendLoop starts the SendLoop of client with StartLoop.
+func StartSendLoop(client Sender, interval time.Duration, network, address string) (stop func()) {
+	return StartLoop(interval, func(ctx context.Context, c <-chan time.Time) {
+		client.SendLoop(ctx, c, network, address)
+	})
+}
+
+// StartWriteLoop starts the WriteLoop of client with StartLoop.
+func StartWriteLoop(client Sender, interval time.Duration, w io.Writer) (stop func()) {
+	return StartLoop(interval, func(ctx context.Context, c <-chan time.Time) {
+		client.WriteLoop(ctx, c, w)
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xkit
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartLoop(t *testing.T) {
+	var ticks, returned int64
+	stop := StartLoop(time.Millisecond, func(ctx context.Context, c <-chan time.Time) {
+		defer atomic.StoreInt64(&returned, 1)
+		for {
+			select {
+			case <-c:
+				atomic.AddInt64(&ticks, 1)
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+	for i := 0; i < 1000 && atomic.LoadInt64(&ticks) < 2; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	stop()
+	stop()
+	assert.True(t, atomic.LoadInt64(&ticks) >= 2)
+	assert.EqualValues(t, 1, atomic.LoadInt64(&returned), "stop waits for the loop to return")
+}
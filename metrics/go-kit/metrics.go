@@ -53,6 +53,7 @@ func (g *Gauge) Update(value int64) {
 // Timer is an adapter from go-kit Histogram to jaeger-lib Timer
 type Timer struct {
 	hist kit.Histogram
+	unit time.Duration
 }
 
 // NewTimer creates a new Timer
@@ -60,8 +61,12 @@ func NewTimer(hist kit.Histogram) *Timer {
 	return &Timer{hist: hist}
 }
 
-// Record saves the time passed in.
+// Record saves the time passed in, in seconds unless the Timer has another unit.
 func (t *Timer) Record(delta time.Duration) {
+	if t.unit > 0 {
+		t.hist.Observe(float64(delta) / float64(t.unit))
+		return
+	}
 	t.hist.Observe(delta.Seconds())
 }
 
@@ -0,0 +1,211 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xkit
+
+import (
+	"sync"
+
+	kit "github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/provider"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+// NewProvider returns a go-kit provider.Provider backed by a metrics.Factory, the reverse
+// of Wrap, so that go-kit instrumentation, e.g. endpoint middlewares, reports through it.
+// The number of buckets of the histograms is ignored in favor of the defaults of the
+// Factory, and Stop does nothing.
+func NewProvider(factory metrics.Factory) provider.Provider {
+	return kitProvider{
+		factory: factory,
+		values:  newGaugeValues(),
+	}
+}
+
+type kitProvider struct {
+	factory metrics.Factory
+	values  *gaugeValues
+}
+
+func (p kitProvider) NewCounter(name string) kit.Counter {
+	return NewKitCounter(p.factory, name)
+}
+
+func (p kitProvider) NewGauge(name string) kit.Gauge {
+	return newKitGauge(p.factory, name, nil, p.values)
+}
+
+func (p kitProvider) NewHistogram(name string, _ int) kit.Histogram {
+	return NewKitHistogram(p.factory, name)
+}
+
+func (p kitProvider) Stop() {}
+
+// labels holds the label values of a go-kit metric, which become the tags of
+// the metrics.Factory metric.
+type labels []string
+
+// with returns the label values followed by more label values, where a missing
+// value is set to "unknown" like go-kit does.
+func (l labels) with(labelValues []string) labels {
+	if len(labelValues)%2 != 0 {
+		labelValues = append(labelValues, "unknown")
+	}
+	return append(append(labels(nil), l...), labelValues...)
+}
+
+func (l labels) tags() map[string]string {
+	if len(l) == 0 {
+		return nil
+	}
+	tags := make(map[string]string, len(l)/2)
+	for i := 0; i < len(l); i += 2 {
+		tags[l[i]] = l[i+1]
+	}
+	return tags
+}
+
+// kitCounter implements go-kit metrics.Counter on top of a metrics.Factory.
+type kitCounter struct {
+	factory metrics.Factory
+	name    string
+	labels  labels
+	once    sync.Once
+	counter metrics.Counter
+}
+
+// NewKitCounter returns a go-kit metrics.Counter backed by a counter of the factory.
+// The counter is created on the first Add, with the label values given to With as tags,
+// so that a counter only used with labels is not also created without tags.
+// The deltas are truncated to integers.
+func NewKitCounter(factory metrics.Factory, name string) kit.Counter {
+	return newKitCounter(factory, name, nil)
+}
+
+func newKitCounter(factory metrics.Factory, name string, labels labels) *kitCounter {
+	return &kitCounter{
+		factory: factory,
+		name:    name,
+		labels:  labels,
+	}
+}
+
+func (c *kitCounter) With(labelValues ...string) kit.Counter {
+	return newKitCounter(c.factory, c.name, c.labels.with(labelValues))
+}
+
+func (c *kitCounter) Add(delta float64) {
+	c.once.Do(func() {
+		c.counter = c.factory.Counter(metrics.Options{Name: c.name, Tags: c.labels.tags()})
+	})
+	c.counter.Inc(int64(delta))
+}
+
+// gaugeValues keeps the values of the gauges by name and tags,
+// to implement Add on top of metrics.Gauge.
+type gaugeValues struct {
+	sync.Mutex
+	values map[string]float64
+}
+
+func newGaugeValues() *gaugeValues {
+	return &gaugeValues{values: make(map[string]float64)}
+}
+
+// kitGauge implements go-kit metrics.Gauge on top of a metrics.Factory.
+type kitGauge struct {
+	factory metrics.Factory
+	name    string
+	labels  labels
+	key     string
+	values  *gaugeValues
+	once    sync.Once
+	gauge   metrics.Gauge
+}
+
+// NewKitGauge returns a go-kit metrics.Gauge backed by a gauge of the factory.
+// The gauge is created on the first update, with the label values given to With as tags.
+// The values are truncated to integers when they are reported.
+func NewKitGauge(factory metrics.Factory, name string) kit.Gauge {
+	return newKitGauge(factory, name, nil, newGaugeValues())
+}
+
+func newKitGauge(factory metrics.Factory, name string, labels labels, values *gaugeValues) *kitGauge {
+	return &kitGauge{
+		factory: factory,
+		name:    name,
+		labels:  labels,
+		key:     metrics.GetKey(name, labels.tags(), "|", "="),
+		values:  values,
+	}
+}
+
+func (g *kitGauge) With(labelValues ...string) kit.Gauge {
+	return newKitGauge(g.factory, g.name, g.labels.with(labelValues), g.values)
+}
+
+func (g *kitGauge) Set(value float64) {
+	g.update(func(float64) float64 { return value })
+}
+
+func (g *kitGauge) Add(delta float64) {
+	g.update(func(value float64) float64 { return value + delta })
+}
+
+func (g *kitGauge) update(f func(value float64) float64) {
+	g.once.Do(func() {
+		g.gauge = g.factory.Gauge(metrics.Options{Name: g.name, Tags: g.labels.tags()})
+	})
+	g.values.Lock()
+	defer g.values.Unlock()
+	value := f(g.values.values[g.key])
+	g.values.values[g.key] = value
+	g.gauge.Update(int64(value))
+}
+
+// kitHistogram implements go-kit metrics.Histogram on top of a metrics.Factory.
+type kitHistogram struct {
+	factory   metrics.Factory
+	name      string
+	labels    labels
+	once      sync.Once
+	histogram metrics.Histogram
+}
+
+// NewKitHistogram returns a go-kit metrics.Histogram backed by a histogram of the factory,
+// with the default buckets of the factory. The histogram is created on the first Observe,
+// with the label values given to With as tags.
+func NewKitHistogram(factory metrics.Factory, name string) kit.Histogram {
+	return newKitHistogram(factory, name, nil)
+}
+
+func newKitHistogram(factory metrics.Factory, name string, labels labels) *kitHistogram {
+	return &kitHistogram{
+		factory: factory,
+		name:    name,
+		labels:  labels,
+	}
+}
+
+func (h *kitHistogram) With(labelValues ...string) kit.Histogram {
+	return newKitHistogram(h.factory, h.name, h.labels.with(labelValues))
+}
+
+func (h *kitHistogram) Observe(value float64) {
+	h.once.Do(func() {
+		h.histogram = h.factory.Histogram(metrics.HistogramOptions{Name: h.name, Tags: h.labels.tags()})
+	})
+	h.histogram.Record(value)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/provider"
+
+	"github.com/uber/jaeger-lib/metrics/go-kit"
+)
+
+// Factory is a metrics factory using a go-kit provider.Provider, e.g. one chosen
+// by configuration among the backends of the go-kit provider package.
+type Factory struct {
+	provider provider.Provider
+	buckets  int
+}
+
+// NewFactory creates a new metrics factory using a go-kit provider.
+// buckets is the number of buckets to be used in histograms, when the provider uses it.
+// The providers create metrics without label names, e.g. the Prometheus one,
+// so the tags are flattened into the names.
+func NewFactory(provider provider.Provider, buckets int) *Factory {
+	return &Factory{
+		provider: provider,
+		buckets:  buckets,
+	}
+}
+
+// Counter implements xkit.Factory.
+func (f *Factory) Counter(name string) metrics.Counter {
+	return f.provider.NewCounter(name)
+}
+
+// Histogram implements xkit.Factory.
+func (f *Factory) Histogram(name string) metrics.Histogram {
+	return f.provider.NewHistogram(name, f.buckets)
+}
+
+// Gauge implements xkit.Factory.
+func (f *Factory) Gauge(name string) metrics.Gauge {
+	return f.provider.NewGauge(name)
+}
+
+// Capabilities implements xkit.Factory.
+func (f *Factory) Capabilities() xkit.Capabilities {
+	return xkit.Capabilities{Tagging: false}
+}
+
+// Stop stops the provider, e.g. its send loop.
+func (f *Factory) Stop() {
+	f.provider.Stop()
+}
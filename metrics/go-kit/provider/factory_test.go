@@ -0,0 +1,63 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/metrics/provider"
+	"github.com/go-kit/kit/metrics/statsd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/go-kit"
+)
+
+var _ xkit.Factory = new(Factory)
+
+func TestFactory(t *testing.T) {
+	client := statsd.New("", log.NewNopLogger())
+	stopped := false
+	pf := NewFactory(provider.NewStatsdProvider(client, func() { stopped = true }), 10)
+	assert.False(t, pf.Capabilities().Tagging)
+	wf := xkit.Wrap("namespace", pf)
+
+	wf.Counter(metrics.Options{Name: "counter", Tags: map[string]string{"x": "y"}}).Inc(7)
+	wf.Gauge(metrics.Options{Name: "gauge"}).Update(42)
+	wf.Histogram(metrics.HistogramOptions{Name: "histogram"}).Record(3)
+
+	var buf bytes.Buffer
+	_, err := client.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"namespace.counter.x_y:7.000000|c",
+		"namespace.gauge:42.000000|g",
+		"namespace.histogram:3.000000|ms",
+	}, strings.Split(strings.TrimSpace(buf.String()), "\n"))
+
+	pf.Stop()
+	assert.True(t, stopped)
+}
+
+func TestDiscardProvider(t *testing.T) {
+	wf := xkit.Wrap("", NewFactory(provider.NewDiscardProvider(), 10))
+	wf.Counter(metrics.Options{Name: "counter"}).Inc(1)
+	wf.Timer(metrics.TimerOptions{Name: "timer"}).Record(time.Second)
+}
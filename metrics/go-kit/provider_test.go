@@ -0,0 +1,79 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xkit
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/metrics/provider"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+	jprom "github.com/uber/jaeger-lib/metrics/prometheus"
+)
+
+var _ provider.Provider = NewProvider(nil)
+
+func TestProvider(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	p := NewProvider(f)
+	defer p.Stop()
+
+	counter := p.NewCounter("requests")
+	counter.With("method", "GET").Add(2)
+	counter.With("method", "GET").Add(1.5)
+	counter.With("method", "PUT", "code").Add(1)
+
+	gauge := p.NewGauge("in-flight").With("method", "GET")
+	gauge.Set(2)
+	gauge.Add(3)
+	p.NewGauge("in-flight").With("method", "GET").Add(-1)
+	p.NewGauge("queue").Add(7)
+
+	histogram := p.NewHistogram("latency", 50).With("method", "GET")
+	histogram.Observe(42)
+
+	f.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "requests", Tags: map[string]string{"method": "GET"}, Value: 3},
+		metricstest.ExpectedMetric{Name: "requests", Tags: map[string]string{"method": "PUT", "code": "unknown"}, Value: 1},
+	)
+	f.AssertGaugeMetrics(t,
+		metricstest.ExpectedMetric{Name: "in-flight", Tags: map[string]string{"method": "GET"}, Value: 4},
+		metricstest.ExpectedMetric{Name: "queue", Value: 7},
+	)
+	_, gauges := f.Snapshot()
+	assert.InDelta(t, 42, gauges["latency|method=GET.P50"], 2, "metricstest keeps one significant digit")
+}
+
+// TestProviderPrometheus checks that metrics only used with labels are not also
+// registered without labels, which Prometheus rejects.
+func TestProviderPrometheus(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	p := NewProvider(jprom.New(jprom.WithRegisterer(registry)))
+
+	p.NewCounter("requests").With("method", "GET").Add(1)
+	p.NewHistogram("latency", 0).With("method", "GET").Observe(0.5)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 2)
+	for _, family := range families {
+		require.Len(t, family.GetMetric(), 1)
+		assert.Equal(t, "method", family.GetMetric()[0].GetLabel()[0].GetName())
+	}
+}
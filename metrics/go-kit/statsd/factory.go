@@ -0,0 +1,69 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsd
+
+import (
+	"io"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/statsd"
+
+	"github.com/uber/jaeger-lib/metrics/go-kit"
+)
+
+// NewFactory creates a new metrics factory using go-kit statsd package.
+// sampleRate is the sample rate of the counters and histograms, 1 to send everything.
+// StatsD has no histograms, so Timers and Histograms are sent as timings,
+// with Timers in milliseconds and Histograms with their values as is.
+func NewFactory(client *statsd.Statsd, sampleRate float64) xkit.Factory {
+	return factory{
+		client:     client,
+		sampleRate: sampleRate,
+	}
+}
+
+type factory struct {
+	client     *statsd.Statsd
+	sampleRate float64
+}
+
+func (f factory) Counter(name string) metrics.Counter {
+	return f.client.NewCounter(name, f.sampleRate)
+}
+
+func (f factory) Histogram(name string) metrics.Histogram {
+	return f.client.NewTiming(name, f.sampleRate)
+}
+
+func (f factory) Gauge(name string) metrics.Gauge {
+	return f.client.NewGauge(name)
+}
+
+func (f factory) Capabilities() xkit.Capabilities {
+	return xkit.Capabilities{Tagging: false, TimerUnit: time.Millisecond}
+}
+
+// StartSendLoop starts sending the metrics to a StatsD server every interval,
+// and returns a function stopping it, e.g. to pass to provider.NewStatsdProvider.
+func StartSendLoop(client *statsd.Statsd, interval time.Duration, network, address string) (stop func()) {
+	return xkit.StartSendLoop(client, interval, network, address)
+}
+
+// StartWriteLoop starts writing the metrics to w every interval,
+// and returns a function stopping it.
+func StartWriteLoop(client *statsd.Statsd, interval time.Duration, w io.Writer) (stop func()) {
+	return xkit.StartWriteLoop(client, interval, w)
+}
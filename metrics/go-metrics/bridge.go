@@ -0,0 +1,273 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xgometrics
+
+import (
+	"sync"
+	"time"
+
+	gometrics "github.com/rcrowley/go-metrics"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/internal/percentile"
+)
+
+// Bridge periodically walks a go-metrics Registry and mirrors its metrics into a
+// metrics.Factory, with the names parsed by the patterns of WithNamePatterns:
+//
+//   - Counters, which go-metrics allows to decrement, are mirrored as Gauges.
+//   - Gauges are mirrored as Gauges, the float64 ones are truncated to integers.
+//   - Meters are mirrored as Counters incremented by the count marked since the previous
+//     walk. The rates can be computed by the backends.
+//   - Histograms and Timers are mirrored in a namespace named after the metric, with a
+//     Counter "count" incremented like the ones of Meters, and Gauges "min", "max", "mean"
+//     and one per percentile, e.g. "p99", of the values sampled by go-metrics.
+//     Timer statistics are in the unit of WithDurationUnit.
+//
+// Healthchecks and unknown types are ignored. The metrics stay in the Factory after
+// they are unregistered from the go-metrics Registry.
+type Bridge struct {
+	registry gometrics.Registry
+	factory  metrics.Factory
+	options  *options
+	lock     sync.Mutex
+	mirrors  map[string]mirror
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	once     sync.Once
+}
+
+// NewBridge creates a Bridge mirroring registry into factory, and starts walking
+// the registry on the interval of WithInterval.
+func NewBridge(registry gometrics.Registry, factory metrics.Factory, opts ...Option) *Bridge {
+	options := applyOptions(opts)
+	b := &Bridge{
+		registry: registry,
+		factory:  factory,
+		options:  options,
+		mirrors:  make(map[string]mirror),
+		stop:     make(chan struct{}),
+	}
+	if options.interval > 0 {
+		b.wg.Add(1)
+		go b.loop(options.interval)
+	}
+	return b
+}
+
+func (b *Bridge) loop(interval time.Duration) {
+	defer b.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			b.Flush()
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+// Flush walks the registry and updates the mirrored metrics.
+func (b *Bridge) Flush() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.registry.Each(func(name string, metric interface{}) {
+		if m, ok := b.mirrors[name]; ok && m.update(metric) {
+			return
+		}
+		// first seen, or re-registered with another type
+		m := b.newMirror(name, metric)
+		if m == nil {
+			delete(b.mirrors, name)
+			return
+		}
+		b.mirrors[name] = m
+		m.update(metric)
+	})
+}
+
+// Stop stops the periodic walks and mirrors the registry a last time.
+func (b *Bridge) Stop() {
+	b.once.Do(func() {
+		close(b.stop)
+		b.wg.Wait()
+		b.Flush()
+	})
+}
+
+func (b *Bridge) newMirror(name string, metric interface{}) mirror {
+	name, tags := b.options.parse(name)
+	options := metrics.Options{Name: name, Tags: tags}
+	switch metric.(type) {
+	case gometrics.Counter:
+		return &counterMirror{gauge: b.factory.Gauge(options)}
+	case gometrics.Gauge:
+		return &gaugeMirror{gauge: b.factory.Gauge(options)}
+	case gometrics.GaugeFloat64:
+		return &gaugeFloat64Mirror{gauge: b.factory.Gauge(options)}
+	case gometrics.Meter:
+		return &meterMirror{count: cumulative{counter: b.factory.Counter(options)}}
+	case gometrics.Histogram:
+		return b.newDistributionMirror(name, tags, 1, func(metric interface{}) (distribution, bool) {
+			h, ok := metric.(gometrics.Histogram)
+			if !ok {
+				return nil, false
+			}
+			return h.Snapshot(), true
+		})
+	case gometrics.Timer:
+		return b.newDistributionMirror(name, tags, float64(b.options.durationUnit), func(metric interface{}) (distribution, bool) {
+			t, ok := metric.(gometrics.Timer)
+			if !ok {
+				return nil, false
+			}
+			return t.Snapshot(), true
+		})
+	}
+	return nil
+}
+
+func (b *Bridge) newDistributionMirror(
+	name string,
+	tags map[string]string,
+	unit float64,
+	snapshot func(metric interface{}) (distribution, bool),
+) mirror {
+	ns := b.factory.Namespace(metrics.NSOptions{Name: name, Tags: tags})
+	m := &distributionMirror{
+		snapshot:    snapshot,
+		unit:        unit,
+		count:       cumulative{counter: ns.Counter(metrics.Options{Name: "count"})},
+		min:         ns.Gauge(metrics.Options{Name: "min"}),
+		max:         ns.Gauge(metrics.Options{Name: "max"}),
+		mean:        ns.Gauge(metrics.Options{Name: "mean"}),
+		quantiles:   make([]float64, len(b.options.percentiles)),
+		percentiles: make([]metrics.Gauge, len(b.options.percentiles)),
+	}
+	for i, p := range b.options.percentiles {
+		m.quantiles[i] = p / 100
+		m.percentiles[i] = ns.Gauge(metrics.Options{Name: percentile.Name(p)})
+	}
+	return m
+}
+
+// mirror updates the metrics mirroring a go-metrics metric.
+type mirror interface {
+	// update returns false if metric is not of the type of the mirror.
+	update(metric interface{}) bool
+}
+
+type counterMirror struct {
+	gauge metrics.Gauge
+}
+
+func (m *counterMirror) update(metric interface{}) bool {
+	c, ok := metric.(gometrics.Counter)
+	if ok {
+		m.gauge.Update(c.Count())
+	}
+	return ok
+}
+
+type gaugeMirror struct {
+	gauge metrics.Gauge
+}
+
+func (m *gaugeMirror) update(metric interface{}) bool {
+	g, ok := metric.(gometrics.Gauge)
+	if ok {
+		m.gauge.Update(g.Value())
+	}
+	return ok
+}
+
+type gaugeFloat64Mirror struct {
+	gauge metrics.Gauge
+}
+
+func (m *gaugeFloat64Mirror) update(metric interface{}) bool {
+	g, ok := metric.(gometrics.GaugeFloat64)
+	if ok {
+		m.gauge.Update(int64(g.Value()))
+	}
+	return ok
+}
+
+// cumulative converts the cumulative counts of go-metrics into Counter increments.
+type cumulative struct {
+	counter metrics.Counter
+	last    int64
+}
+
+// inc increments the counter by the count since the previous call. A count lower
+// than the previous one means the metric was cleared and counted from zero again.
+func (c *cumulative) inc(count int64) {
+	if count < c.last {
+		c.last = 0
+	}
+	if count > c.last {
+		c.counter.Inc(count - c.last)
+	}
+	c.last = count
+}
+
+type meterMirror struct {
+	count cumulative
+}
+
+func (m *meterMirror) update(metric interface{}) bool {
+	meter, ok := metric.(gometrics.Meter)
+	if ok {
+		m.count.inc(meter.Count())
+	}
+	return ok
+}
+
+// distribution is the part of the go-metrics Histogram and Timer snapshots reported by a Bridge.
+type distribution interface {
+	Count() int64
+	Min() int64
+	Max() int64
+	Mean() float64
+	Percentiles([]float64) []float64
+}
+
+type distributionMirror struct {
+	snapshot    func(metric interface{}) (distribution, bool)
+	unit        float64
+	count       cumulative
+	min         metrics.Gauge
+	max         metrics.Gauge
+	mean        metrics.Gauge
+	quantiles   []float64
+	percentiles []metrics.Gauge
+}
+
+func (m *distributionMirror) update(metric interface{}) bool {
+	d, ok := m.snapshot(metric)
+	if !ok {
+		return false
+	}
+	m.count.inc(d.Count())
+	m.min.Update(int64(float64(d.Min()) / m.unit))
+	m.max.Update(int64(float64(d.Max()) / m.unit))
+	m.mean.Update(int64(d.Mean() / m.unit))
+	for i, v := range d.Percentiles(m.quantiles) {
+		m.percentiles[i].Update(int64(v / m.unit))
+	}
+	return true
+}
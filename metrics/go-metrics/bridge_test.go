@@ -0,0 +1,131 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xgometrics
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+)
+
+var brokerPattern = regexp.MustCompile(`^(?P<name>.+)-for-broker-(?P<broker>\d+)$`)
+
+func TestParse(t *testing.T) {
+	options := applyOptions([]Option{WithNamePatterns(
+		regexp.MustCompile(`^(?P<name>[a-z]+)(-(?P<topic>[a-z]+))?$`),
+		regexp.MustCompile(`^node-(?P<node>\d+)$`),
+	)})
+	tests := []struct {
+		name     string
+		expected string
+		tags     map[string]string
+	}{
+		{name: "requests-orders", expected: "requests", tags: map[string]string{"topic": "orders"}},
+		{name: "requests", expected: "requests", tags: map[string]string{}},
+		{name: "node-2", expected: "node-2", tags: map[string]string{"node": "2"}},
+		{name: "Other-Name", expected: "Other-Name"},
+	}
+	for _, test := range tests {
+		name, tags := options.parse(test.name)
+		assert.Equal(t, test.expected, name, test.name)
+		assert.Equal(t, test.tags, tags, test.name)
+	}
+}
+
+func TestBridge(t *testing.T) {
+	registry := gometrics.NewRegistry()
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	b := NewBridge(registry, f, WithInterval(0), WithNamePatterns(brokerPattern), WithPercentiles([]float64{50, 99.9}))
+
+	c := gometrics.NewRegisteredCounter("in-flight", registry)
+	c.Inc(5)
+	c.Dec(2)
+	gometrics.NewRegisteredGauge("queue", registry).Update(7)
+	gometrics.NewRegisteredGaugeFloat64("ratio", registry).Update(0.9)
+	gometrics.NewRegisteredFunctionalGauge("functional", registry, func() int64 { return 11 })
+	m := gometrics.NewRegisteredMeter("request-rate-for-broker-1", registry)
+	m.Mark(3)
+	h := gometrics.NewRegisteredHistogram("size", registry, gometrics.NewUniformSample(1028))
+	for i := int64(1); i <= 100; i++ {
+		h.Update(i)
+	}
+	tm := gometrics.NewRegisteredTimer("latency", registry)
+	tm.Update(10 * time.Millisecond)
+	tm.Update(30 * time.Millisecond)
+	registry.Register("health", gometrics.NewHealthcheck(func(gometrics.Healthcheck) {}))
+
+	b.Flush()
+	f.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "request-rate", Tags: map[string]string{"broker": "1"}, Value: 3},
+		metricstest.ExpectedMetric{Name: "size.count", Value: 100},
+		metricstest.ExpectedMetric{Name: "latency.count", Value: 2},
+	)
+	f.AssertGaugeMetrics(t,
+		metricstest.ExpectedMetric{Name: "in-flight", Value: 3},
+		metricstest.ExpectedMetric{Name: "queue", Value: 7},
+		metricstest.ExpectedMetric{Name: "ratio", Value: 0},
+		metricstest.ExpectedMetric{Name: "functional", Value: 11},
+		metricstest.ExpectedMetric{Name: "size.min", Value: 1},
+		metricstest.ExpectedMetric{Name: "size.max", Value: 100},
+		metricstest.ExpectedMetric{Name: "size.mean", Value: 50},
+		metricstest.ExpectedMetric{Name: "size.p50", Value: 50},
+		metricstest.ExpectedMetric{Name: "size.p99_9", Value: 100},
+		metricstest.ExpectedMetric{Name: "latency.min", Value: 10},
+		metricstest.ExpectedMetric{Name: "latency.max", Value: 30},
+		metricstest.ExpectedMetric{Name: "latency.mean", Value: 20},
+		metricstest.ExpectedMetric{Name: "latency.p50", Value: 20},
+		metricstest.ExpectedMetric{Name: "latency.p99_9", Value: 30},
+	)
+
+	// counts are mirrored as increments, and a cleared metric starts over
+	m.Mark(2)
+	h.Clear()
+	h.Update(1)
+	b.Stop()
+	b.Stop()
+	f.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "request-rate", Tags: map[string]string{"broker": "1"}, Value: 5},
+		metricstest.ExpectedMetric{Name: "size.count", Value: 101},
+	)
+	f.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "size.max", Value: 1})
+
+	// a metric re-registered with another type gets new mirrors
+	registry.Unregister("in-flight")
+	gometrics.NewRegisteredGauge("in-flight", registry).Update(42)
+	b.Flush()
+	f.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "in-flight", Value: 42})
+}
+
+func TestBridgeLoop(t *testing.T) {
+	registry := gometrics.NewRegistry()
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	gometrics.NewRegisteredGauge("queue", registry).Update(7)
+	b := NewBridge(registry, f, WithInterval(time.Millisecond))
+	for i := 0; i < 1000; i++ {
+		if _, gauges := f.Snapshot(); gauges["queue"] == 7 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	b.Stop()
+	f.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "queue", Value: 7})
+}
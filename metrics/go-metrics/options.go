@@ -0,0 +1,124 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package xgometrics bridges github.com/rcrowley/go-metrics registries and metrics.Factory,
+// in both directions: a Bridge mirrors the metrics of a go-metrics Registry into a Factory,
+// and NewRegistry returns a go-metrics Registry whose metrics also record into a Factory.
+package xgometrics
+
+import (
+	"regexp"
+	"time"
+)
+
+const (
+	// DefaultInterval is how often a Bridge walks the registry.
+	DefaultInterval = 10 * time.Second
+
+	// DefaultDurationUnit is the unit of the Timer statistics reported by a Bridge,
+	// like the reporters of go-metrics.
+	DefaultDurationUnit = time.Millisecond
+)
+
+// DefaultPercentiles are the percentiles of Timers and Histograms reported by a Bridge.
+var DefaultPercentiles = []float64{50, 75, 95, 99}
+
+type options struct {
+	interval     time.Duration
+	patterns     []*regexp.Regexp
+	durationUnit time.Duration
+	percentiles  []float64
+}
+
+// Option is a function that sets some option for NewBridge and NewRegistry.
+type Option func(*options)
+
+// WithInterval returns an option that sets how often a Bridge walks the registry.
+// If not used, we fallback to DefaultInterval. A non-positive interval disables
+// the periodic walks, Flush must be called instead.
+func WithInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.interval = interval
+	}
+}
+
+// WithNamePatterns returns an option that sets the regular expressions used to parse
+// go-metrics names into a name and tags. The first pattern matching a name is used:
+// its named groups become tags, except the group "name" which becomes the name, e.g.
+//
+//	^(?P<name>.+)-for-broker-(?P<broker>\d+)$
+//
+// parses "request-rate-for-broker-1" into the name "request-rate" and the tag broker=1.
+// Groups that do not participate in the match are ignored. Without a "name" group,
+// or if no pattern matches, the name is used verbatim.
+func WithNamePatterns(patterns ...*regexp.Regexp) Option {
+	return func(opts *options) {
+		opts.patterns = patterns
+	}
+}
+
+// WithDurationUnit returns an option that sets the unit of the Timer statistics
+// reported by a Bridge. If not used, we fallback to DefaultDurationUnit.
+func WithDurationUnit(unit time.Duration) Option {
+	return func(opts *options) {
+		if unit > 0 {
+			opts.durationUnit = unit
+		}
+	}
+}
+
+// WithPercentiles returns an option that sets the percentiles, between 0 and 100,
+// of Timers and Histograms reported by a Bridge. If not used, we fallback to DefaultPercentiles.
+func WithPercentiles(percentiles []float64) Option {
+	return func(opts *options) {
+		opts.percentiles = percentiles
+	}
+}
+
+func applyOptions(opts []Option) *options {
+	options := &options{
+		interval:     DefaultInterval,
+		durationUnit: DefaultDurationUnit,
+		percentiles:  DefaultPercentiles,
+	}
+	for _, o := range opts {
+		o(options)
+	}
+	return options
+}
+
+// parse splits a go-metrics name into a name and tags with the first matching pattern.
+func (o *options) parse(name string) (string, map[string]string) {
+	for _, p := range o.patterns {
+		match := p.FindStringSubmatchIndex(name)
+		if match == nil {
+			continue
+		}
+		parsed := name
+		tags := make(map[string]string)
+		for i, group := range p.SubexpNames() {
+			start, end := match[2*i], match[2*i+1]
+			if i == 0 || group == "" || start < 0 {
+				continue
+			}
+			if group == "name" {
+				parsed = name[start:end]
+			} else {
+				tags[group] = name[start:end]
+			}
+		}
+		return parsed, tags
+	}
+	return name, nil
+}
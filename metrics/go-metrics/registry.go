@@ -0,0 +1,185 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xgometrics
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	gometrics "github.com/rcrowley/go-metrics"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+// NewRegistry creates a go-metrics Registry whose metrics also record into factory,
+// so that libraries publishing into a go-metrics Registry can be given one backed by
+// our metrics. The names are parsed by the patterns of WithNamePatterns.
+//
+// The go-metrics metrics still hold their own values, and the updates are forwarded:
+//
+//   - Counters, which go-metrics allows to decrement, set a Gauge to their count.
+//   - Gauges update a Gauge, the float64 ones truncated to integers.
+//   - Meters increment a Counter.
+//   - Histograms and Timers record into a Histogram and a Timer.
+//
+// Functional gauges are not forwarded, as they are never updated; a Bridge can mirror
+// them. Healthchecks and unknown types are registered as is.
+func NewRegistry(factory metrics.Factory, opts ...Option) gometrics.Registry {
+	return &registry{
+		Registry: gometrics.NewRegistry(),
+		factory:  factory,
+		options:  applyOptions(opts),
+	}
+}
+
+type registry struct {
+	gometrics.Registry
+	factory metrics.Factory
+	options *options
+	// serializes the registrations, so that metrics are created once per name
+	lock sync.Mutex
+}
+
+func (r *registry) GetOrRegister(name string, i interface{}) interface{} {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if metric := r.Registry.Get(name); metric != nil {
+		return metric
+	}
+	if v := reflect.ValueOf(i); v.Kind() == reflect.Func {
+		i = v.Call(nil)[0].Interface()
+	}
+	return r.Registry.GetOrRegister(name, r.wrap(name, i))
+}
+
+func (r *registry) Register(name string, i interface{}) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.Registry.Get(name) != nil {
+		return gometrics.DuplicateMetric(name)
+	}
+	return r.Registry.Register(name, r.wrap(name, i))
+}
+
+func (r *registry) wrap(name string, i interface{}) interface{} {
+	name, tags := r.options.parse(name)
+	options := metrics.Options{Name: name, Tags: tags}
+	switch m := i.(type) {
+	case gometrics.FunctionalGauge, gometrics.FunctionalGaugeFloat64:
+		return i
+	case gometrics.Counter:
+		return &counter{Counter: m, gauge: r.factory.Gauge(options)}
+	case gometrics.Gauge:
+		return &gauge{Gauge: m, gauge: r.factory.Gauge(options)}
+	case gometrics.GaugeFloat64:
+		return &gaugeFloat64{GaugeFloat64: m, gauge: r.factory.Gauge(options)}
+	case gometrics.Meter:
+		return &meter{Meter: m, counter: r.factory.Counter(options)}
+	case gometrics.Histogram:
+		return &histogram{Histogram: m, histogram: r.factory.Histogram(metrics.HistogramOptions{Name: name, Tags: tags})}
+	case gometrics.Timer:
+		return &timer{Timer: m, timer: r.factory.Timer(metrics.TimerOptions{Name: name, Tags: tags})}
+	}
+	return i
+}
+
+type counter struct {
+	gometrics.Counter
+	gauge metrics.Gauge
+	// keeps the gauge in the order of the updates of the counter
+	lock sync.Mutex
+}
+
+func (c *counter) Clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.Counter.Clear()
+	c.gauge.Update(c.Counter.Count())
+}
+
+func (c *counter) Dec(delta int64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.Counter.Dec(delta)
+	c.gauge.Update(c.Counter.Count())
+}
+
+func (c *counter) Inc(delta int64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.Counter.Inc(delta)
+	c.gauge.Update(c.Counter.Count())
+}
+
+type gauge struct {
+	gometrics.Gauge
+	gauge metrics.Gauge
+}
+
+func (g *gauge) Update(value int64) {
+	g.Gauge.Update(value)
+	g.gauge.Update(value)
+}
+
+type gaugeFloat64 struct {
+	gometrics.GaugeFloat64
+	gauge metrics.Gauge
+}
+
+func (g *gaugeFloat64) Update(value float64) {
+	g.GaugeFloat64.Update(value)
+	g.gauge.Update(int64(value))
+}
+
+type meter struct {
+	gometrics.Meter
+	counter metrics.Counter
+}
+
+func (m *meter) Mark(n int64) {
+	m.Meter.Mark(n)
+	m.counter.Inc(n)
+}
+
+type histogram struct {
+	gometrics.Histogram
+	histogram metrics.Histogram
+}
+
+func (h *histogram) Update(value int64) {
+	h.Histogram.Update(value)
+	h.histogram.Record(float64(value))
+}
+
+type timer struct {
+	gometrics.Timer
+	timer metrics.Timer
+}
+
+func (t *timer) Time(f func()) {
+	start := time.Now()
+	f()
+	t.Update(time.Since(start))
+}
+
+func (t *timer) Update(d time.Duration) {
+	t.Timer.Update(d)
+	t.timer.Record(d)
+}
+
+func (t *timer) UpdateSince(start time.Time) {
+	t.Update(time.Since(start))
+}
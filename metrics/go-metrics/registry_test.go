@@ -0,0 +1,72 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xgometrics
+
+import (
+	"testing"
+	"time"
+
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+)
+
+func TestRegistry(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	r := NewRegistry(f, WithNamePatterns(brokerPattern))
+
+	c := gometrics.GetOrRegisterCounter("in-flight", r)
+	c.Inc(5)
+	c.Dec(2)
+	assert.EqualValues(t, 3, c.Count(), "the go-metrics values are kept")
+	gometrics.GetOrRegisterGauge("queue", r).Update(7)
+	gometrics.GetOrRegisterGaugeFloat64("ratio", r).Update(2.5)
+	gometrics.GetOrRegisterMeter("request-rate-for-broker-1", r).Mark(3)
+	gometrics.GetOrRegisterMeter("request-rate-for-broker-1", r).Mark(2)
+	gometrics.GetOrRegisterHistogram("size", r, gometrics.NewUniformSample(1028)).Update(7)
+	tm := gometrics.GetOrRegisterTimer("latency", r)
+	tm.Update(3 * time.Millisecond)
+	tm.Time(func() {})
+	tm.UpdateSince(time.Now())
+	assert.EqualValues(t, 3, tm.Count())
+	gometrics.NewRegisteredFunctionalGauge("functional", r, func() int64 { return 11 })
+
+	assert.EqualValues(t, 5, r.Get("request-rate-for-broker-1").(gometrics.Meter).Count())
+	assert.Equal(t, gometrics.DuplicateMetric("queue"), r.Register("queue", gometrics.NewGauge()))
+
+	f.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "request-rate", Tags: map[string]string{"broker": "1"}, Value: 5},
+	)
+	f.AssertGaugeMetrics(t,
+		metricstest.ExpectedMetric{Name: "in-flight", Value: 3},
+		metricstest.ExpectedMetric{Name: "queue", Value: 7},
+		metricstest.ExpectedMetric{Name: "ratio", Value: 2},
+		metricstest.ExpectedMetric{Name: "size.P99", Value: 7},
+		metricstest.ExpectedMetric{Name: "latency.P99", Value: 3},
+	)
+	_, gauges := f.Snapshot()
+	assert.NotContains(t, gauges, "functional")
+
+	// the registry can be bridged back, e.g. for the functional gauges
+	b := NewBridge(r, f, WithInterval(0))
+	b.Stop()
+	f.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "functional", Value: 11})
+
+	r.UnregisterAll()
+	require.Nil(t, r.Get("queue"))
+}
@@ -0,0 +1,282 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package goruntime publishes statistics of the Go runtime through any metrics.Factory.
+package goruntime
+
+import (
+	"math"
+	"runtime"
+	runtimemetrics "runtime/metrics"
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/internal/percentile"
+)
+
+const (
+	// DefaultInterval is how often the runtime is sampled.
+	DefaultInterval = 10 * time.Second
+
+	schedLatencies = "/sched/latencies:seconds"
+)
+
+var (
+	// DefaultPercentiles are the percentiles reported for the scheduler latencies.
+	DefaultPercentiles = []float64{50, 90, 99}
+
+	// GCPauseBuckets are the buckets of the Timer of the GC pauses.
+	GCPauseBuckets = []time.Duration{
+		10 * time.Microsecond, 25 * time.Microsecond, 50 * time.Microsecond, 100 * time.Microsecond,
+		250 * time.Microsecond, 500 * time.Microsecond, time.Millisecond, 2500 * time.Microsecond,
+		5 * time.Millisecond, 10 * time.Millisecond, 25 * time.Millisecond, 50 * time.Millisecond,
+		100 * time.Millisecond,
+	}
+)
+
+type options struct {
+	interval    time.Duration
+	percentiles []float64
+}
+
+// Option is a function that sets some option for the Collector constructor.
+type Option func(*options)
+
+// WithInterval returns an option that sets how often the runtime is sampled.
+// If not used, we fallback to DefaultInterval. A non-positive interval disables
+// the periodic samples, Collect must be called instead.
+func WithInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.interval = interval
+	}
+}
+
+// WithPercentiles returns an option that sets the percentiles, between 0 and 100,
+// reported for the scheduler latencies. If not used, we fallback to DefaultPercentiles.
+func WithPercentiles(percentiles []float64) Option {
+	return func(opts *options) {
+		opts.percentiles = percentiles
+	}
+}
+
+// Collector periodically samples the Go runtime and publishes the samples in the
+// namespace "go" of a metrics.Factory, with names following the Go collector of
+// the Prometheus client, e.g. "go.memstats.heap_alloc_bytes":
+//
+//   - Gauges "goroutines", "threads" and "gomaxprocs".
+//   - Gauges of runtime.MemStats in the namespace "memstats": "alloc_bytes", "sys_bytes",
+//     "heap_alloc_bytes", "heap_sys_bytes", "heap_idle_bytes", "heap_inuse_bytes",
+//     "heap_released_bytes", "heap_objects", "stack_inuse_bytes", "stack_sys_bytes",
+//     "next_gc_bytes" and "last_gc_time_seconds".
+//   - Counters of runtime.MemStats in the namespace "memstats": "alloc_bytes_total",
+//     "mallocs_total" and "frees_total".
+//   - A Timer "gc.pauses" recording each stop-the-world GC pause, with GCPauseBuckets.
+//   - Gauges "sched.latency_p50_ns", etc. with the percentiles, in nanoseconds, of the time
+//     goroutines waited to be scheduled since the previous sample.
+type Collector struct {
+	goroutines metrics.Gauge
+	threads    metrics.Gauge
+	gomaxprocs metrics.Gauge
+	memStats   []memStat
+	gcPauses   metrics.Timer
+	sched      []metrics.Gauge
+	quantiles  []float64
+
+	lock        sync.Mutex
+	numGC       uint32
+	samples     []runtimemetrics.Sample
+	schedCounts []uint64
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// memStat publishes a field of runtime.MemStats as a Gauge or a Counter.
+type memStat struct {
+	value   func(*runtime.MemStats) uint64
+	gauge   metrics.Gauge
+	counter metrics.Counter
+	last    uint64
+}
+
+func (m *memStat) update(ms *runtime.MemStats) {
+	value := m.value(ms)
+	if m.gauge != nil {
+		m.gauge.Update(int64(value))
+		return
+	}
+	if value > m.last {
+		m.counter.Inc(int64(value - m.last))
+	}
+	m.last = value
+}
+
+// NewCollector creates a Collector publishing into factory, and starts sampling
+// the runtime on the interval of WithInterval.
+func NewCollector(factory metrics.Factory, opts ...Option) *Collector {
+	options := &options{
+		interval:    DefaultInterval,
+		percentiles: DefaultPercentiles,
+	}
+	for _, o := range opts {
+		o(options)
+	}
+
+	ns := factory.Namespace(metrics.NSOptions{Name: "go"})
+	mem := ns.Namespace(metrics.NSOptions{Name: "memstats"})
+	gauge := func(name string, value func(*runtime.MemStats) uint64) memStat {
+		return memStat{value: value, gauge: mem.Gauge(metrics.Options{Name: name})}
+	}
+	counter := func(name string, value func(*runtime.MemStats) uint64) memStat {
+		return memStat{value: value, counter: mem.Counter(metrics.Options{Name: name})}
+	}
+	c := &Collector{
+		goroutines: ns.Gauge(metrics.Options{Name: "goroutines"}),
+		threads:    ns.Gauge(metrics.Options{Name: "threads"}),
+		gomaxprocs: ns.Gauge(metrics.Options{Name: "gomaxprocs"}),
+		memStats: []memStat{
+			gauge("alloc_bytes", func(ms *runtime.MemStats) uint64 { return ms.Alloc }),
+			gauge("sys_bytes", func(ms *runtime.MemStats) uint64 { return ms.Sys }),
+			gauge("heap_alloc_bytes", func(ms *runtime.MemStats) uint64 { return ms.HeapAlloc }),
+			gauge("heap_sys_bytes", func(ms *runtime.MemStats) uint64 { return ms.HeapSys }),
+			gauge("heap_idle_bytes", func(ms *runtime.MemStats) uint64 { return ms.HeapIdle }),
+			gauge("heap_inuse_bytes", func(ms *runtime.MemStats) uint64 { return ms.HeapInuse }),
+			gauge("heap_released_bytes", func(ms *runtime.MemStats) uint64 { return ms.HeapReleased }),
+			gauge("heap_objects", func(ms *runtime.MemStats) uint64 { return ms.HeapObjects }),
+			gauge("stack_inuse_bytes", func(ms *runtime.MemStats) uint64 { return ms.StackInuse }),
+			gauge("stack_sys_bytes", func(ms *runtime.MemStats) uint64 { return ms.StackSys }),
+			gauge("next_gc_bytes", func(ms *runtime.MemStats) uint64 { return ms.NextGC }),
+			gauge("last_gc_time_seconds", func(ms *runtime.MemStats) uint64 { return ms.LastGC / uint64(time.Second) }),
+			counter("alloc_bytes_total", func(ms *runtime.MemStats) uint64 { return ms.TotalAlloc }),
+			counter("mallocs_total", func(ms *runtime.MemStats) uint64 { return ms.Mallocs }),
+			counter("frees_total", func(ms *runtime.MemStats) uint64 { return ms.Frees }),
+		},
+		gcPauses: ns.Namespace(metrics.NSOptions{Name: "gc"}).Timer(metrics.TimerOptions{
+			Name:    "pauses",
+			Buckets: GCPauseBuckets,
+		}),
+		samples: []runtimemetrics.Sample{{Name: schedLatencies}},
+		stop:    make(chan struct{}),
+	}
+	sched := ns.Namespace(metrics.NSOptions{Name: "sched"})
+	for _, p := range options.percentiles {
+		c.quantiles = append(c.quantiles, p/100)
+		c.sched = append(c.sched, sched.Gauge(metrics.Options{Name: "latency_" + percentile.Name(p) + "_ns"}))
+	}
+
+	if options.interval > 0 {
+		c.wg.Add(1)
+		go c.loop(options.interval)
+	}
+	return c
+}
+
+func (c *Collector) loop(interval time.Duration) {
+	defer c.wg.Done()
+	c.Collect()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.Collect()
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the periodic samples.
+func (c *Collector) Stop() {
+	c.once.Do(func() {
+		close(c.stop)
+		c.wg.Wait()
+	})
+}
+
+// Collect samples the runtime and updates the metrics.
+func (c *Collector) Collect() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.goroutines.Update(int64(runtime.NumGoroutine()))
+	threads, _ := runtime.ThreadCreateProfile(nil)
+	c.threads.Update(int64(threads))
+	c.gomaxprocs.Update(int64(runtime.GOMAXPROCS(0)))
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	for i := range c.memStats {
+		c.memStats[i].update(&ms)
+	}
+	c.collectGCPauses(&ms)
+	c.collectSchedLatencies()
+}
+
+// collectGCPauses records the pauses since the previous sample, as far as they are
+// still in the circular buffer of runtime.MemStats.
+func (c *Collector) collectGCPauses(ms *runtime.MemStats) {
+	first := c.numGC
+	if n := uint32(len(ms.PauseNs)); ms.NumGC-first > n {
+		first = ms.NumGC - n
+	}
+	for i := first; i < ms.NumGC; i++ {
+		c.gcPauses.Record(time.Duration(ms.PauseNs[i%uint32(len(ms.PauseNs))]))
+	}
+	c.numGC = ms.NumGC
+}
+
+func (c *Collector) collectSchedLatencies() {
+	runtimemetrics.Read(c.samples)
+	if c.samples[0].Value.Kind() != runtimemetrics.KindFloat64Histogram {
+		return // not supported by this runtime
+	}
+	h := c.samples[0].Value.Float64Histogram()
+	deltas := make([]uint64, len(h.Counts))
+	var total uint64
+	for i, count := range h.Counts {
+		if i < len(c.schedCounts) {
+			deltas[i] = count - c.schedCounts[i]
+		} else {
+			deltas[i] = count
+		}
+		total += deltas[i]
+	}
+	c.schedCounts = append(c.schedCounts[:0], h.Counts...)
+	if total == 0 {
+		return
+	}
+	for i, q := range c.quantiles {
+		c.sched[i].Update(int64(quantile(h.Buckets, deltas, total, q) * float64(time.Second)))
+	}
+}
+
+// quantile returns the upper bound of the bucket holding the quantile q of the counts,
+// or its lower bound for the last, unbounded, bucket.
+func quantile(buckets []float64, counts []uint64, total uint64, q float64) float64 {
+	rank := uint64(math.Ceil(q * float64(total)))
+	var cumulative uint64
+	for i, count := range counts {
+		cumulative += count
+		if cumulative >= rank && count > 0 {
+			if math.IsInf(buckets[i+1], 1) {
+				return buckets[i]
+			}
+			return buckets[i+1]
+		}
+	}
+	return buckets[len(buckets)-1]
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package goruntime
+
+import (
+	"math"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+)
+
+func TestCollector(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	c := NewCollector(f, WithInterval(0), WithPercentiles([]float64{50, 99.9}))
+	runtime.GC()
+	c.Collect()
+
+	counters, gauges := f.Snapshot()
+	assert.True(t, gauges["go.goroutines"] > 0)
+	assert.True(t, gauges["go.threads"] > 0)
+	assert.EqualValues(t, runtime.GOMAXPROCS(0), gauges["go.gomaxprocs"])
+	assert.True(t, gauges["go.memstats.heap_alloc_bytes"] > 0)
+	assert.True(t, gauges["go.memstats.sys_bytes"] >= gauges["go.memstats.heap_sys_bytes"])
+	assert.InDelta(t, time.Now().Unix(), gauges["go.memstats.last_gc_time_seconds"], 60)
+	assert.Contains(t, gauges, "go.gc.pauses.P99")
+	assert.Contains(t, gauges, "go.sched.latency_p50_ns")
+	assert.Contains(t, gauges, "go.sched.latency_p99_9_ns")
+	mallocs := counters["go.memstats.mallocs_total"]
+	assert.True(t, mallocs > 0)
+	assert.True(t, counters["go.memstats.alloc_bytes_total"] > 0)
+
+	// counters are incremented by the difference between samples
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	c.Collect()
+	counters, _ = f.Snapshot()
+	assert.True(t, counters["go.memstats.mallocs_total"] >= int64(ms.Mallocs))
+	assert.True(t, counters["go.memstats.mallocs_total"] >= mallocs)
+}
+
+func TestCollectGCPauses(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	c := NewCollector(f, WithInterval(0))
+
+	ms := &runtime.MemStats{NumGC: 1000}
+	for i := range ms.PauseNs {
+		ms.PauseNs[i] = uint64(time.Millisecond)
+	}
+	ms.PauseNs[(ms.NumGC-1)%256] = uint64(5 * time.Millisecond)
+	c.collectGCPauses(ms)
+	assert.EqualValues(t, 1000, c.numGC)
+	f.AssertGaugeMetrics(t,
+		metricstest.ExpectedMetric{Name: "go.gc.pauses.P50", Value: 1},
+		metricstest.ExpectedMetric{Name: "go.gc.pauses.P999", Value: 5},
+	)
+
+	// only the pauses since the previous sample are recorded
+	ms.NumGC++
+	ms.PauseNs[(ms.NumGC-1)%256] = uint64(9 * time.Millisecond)
+	c.collectGCPauses(ms)
+	f.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "go.gc.pauses.P999", Value: 9})
+}
+
+func TestQuantile(t *testing.T) {
+	buckets := []float64{math.Inf(-1), 0.001, 0.01, 0.1, math.Inf(1)}
+	tests := []struct {
+		counts   []uint64
+		q        float64
+		expected float64
+	}{
+		{counts: []uint64{0, 10, 0, 0}, q: 0.5, expected: 0.01},
+		{counts: []uint64{0, 5, 5, 0}, q: 0.5, expected: 0.01},
+		{counts: []uint64{0, 5, 5, 0}, q: 0.51, expected: 0.1},
+		{counts: []uint64{0, 5, 4, 1}, q: 0.99, expected: 0.1},
+		{counts: []uint64{1, 0, 0, 0}, q: 0, expected: 0.001},
+	}
+	for _, test := range tests {
+		var total uint64
+		for _, c := range test.counts {
+			total += c
+		}
+		assert.Equal(t, test.expected, quantile(buckets, test.counts, total, test.q), "%v %v", test.counts, test.q)
+	}
+}
+
+func TestCollectorLoop(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	c := NewCollector(f, WithInterval(time.Millisecond))
+	for i := 0; i < 1000; i++ {
+		if _, gauges := f.Snapshot(); gauges["go.goroutines"] > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	c.Stop()
+	c.Stop()
+	_, gauges := f.Snapshot()
+	require.True(t, gauges["go.goroutines"] > 0)
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphite
+
+import (
+	"strings"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics/adapters"
+	"github.com/uber/jaeger-lib/metrics/internal/aggregate"
+)
+
+const (
+	// DefaultFlushInterval is how often the aggregated metrics are written.
+	DefaultFlushInterval = 10 * time.Second
+
+	// DefaultMaxBufferSize is how many bytes of lines are kept while disconnected.
+	DefaultMaxBufferSize = 1 << 20
+
+	// DefaultWriteTimeout is how long writing a flush to the server may take.
+	DefaultWriteTimeout = 5 * time.Second
+)
+
+// DefaultPercentiles are the percentiles reported for Timers and Histograms.
+var DefaultPercentiles = []float64{50, 95, 99}
+
+// Factory implements metrics.Factory by aggregating metrics locally and writing them
+// in the Graphite plaintext protocol, "path value timestamp", on each flush interval.
+// If the server is unreachable, the lines are buffered and Flush returns the error.
+//
+// Counters report the sum of the increments during the interval and Gauges the last value.
+// Timers and Histograms report the count, sum, min, max and mean of the values recorded
+// during the interval, as well as percentiles, e.g. "latency.p99". Timers are in milliseconds.
+type Factory struct {
+	*aggregate.Factory
+	reporter *reporter
+}
+
+type options struct {
+	prefix        string
+	flushInterval time.Duration
+	adapters      adapters.Options
+	tagged        bool
+	sanitizer     func(string) string
+	percentiles   []float64
+	maxBufferSize int
+	dialTimeout   time.Duration
+	writeTimeout  time.Duration
+	minBackoff    time.Duration
+	maxBackoff    time.Duration
+	errorHandler  func(error)
+}
+
+// Option is a function that sets some option for the Factory constructor.
+type Option func(*options)
+
+// WithPrefix returns an option that sets a prefix prepended to all paths,
+// e.g. "jaeger.". The prefix is used verbatim, without a separator.
+func WithPrefix(prefix string) Option {
+	return func(opts *options) {
+		opts.prefix = prefix
+	}
+}
+
+// WithFlushInterval returns an option that sets how often the aggregated metrics
+// are written. If not used, we fallback to DefaultFlushInterval.
+// A non-positive interval disables the periodic flushes, Flush must be called instead.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.flushInterval = interval
+	}
+}
+
+// WithAdaptersOptions returns an option that sets the separators used to build
+// paths from namespaces and tags. If not used, we fallback to the defaults of
+// adapters.Options, e.g. "ns.name.k1_v1.k2_v2".
+func WithAdaptersOptions(adaptersOptions adapters.Options) Option {
+	return func(opts *options) {
+		opts.adapters = adaptersOptions
+	}
+}
+
+// WithTaggedPaths returns an option that enables the Graphite 1.1 tags syntax,
+// e.g. "ns.name;k1=v1;k2=v2", instead of flattening the tags into the path.
+func WithTaggedPaths(tagged bool) Option {
+	return func(opts *options) {
+		opts.tagged = tagged
+	}
+}
+
+// WithSanitizer returns an option that sets the function applied to names, tag keys
+// and tag values before they are assembled into a path. If not used, we fallback
+// to Sanitize.
+func WithSanitizer(sanitizer func(string) string) Option {
+	return func(opts *options) {
+		opts.sanitizer = sanitizer
+	}
+}
+
+// WithPercentiles returns an option that sets the percentiles, between 0 and 100,
+// reported for Timers and Histograms. If not used, we fallback to DefaultPercentiles.
+func WithPercentiles(percentiles []float64) Option {
+	return func(opts *options) {
+		opts.percentiles = percentiles
+	}
+}
+
+// WithMaxBufferSize returns an option that sets how many bytes of lines are kept while
+// the server is unreachable; the oldest flushes are dropped beyond that.
+// If not used, we fallback to DefaultMaxBufferSize.
+func WithMaxBufferSize(size int) Option {
+	return func(opts *options) {
+		opts.maxBufferSize = size
+	}
+}
+
+// WithWriteTimeout returns an option that sets how long writing the lines of a flush
+// may take. When it expires, the connection is closed and the lines are kept in the
+// buffer to be written after reconnecting. A non-positive timeout disables it.
+// If not used, we fallback to DefaultWriteTimeout.
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(opts *options) {
+		opts.writeTimeout = timeout
+	}
+}
+
+// WithReconnectBackoff returns an option that sets the delay before reconnecting after
+// a failed connection attempt, which doubles on each failure from min up to max.
+// If not used, we fallback to 100ms and 30s.
+func WithReconnectBackoff(min, max time.Duration) Option {
+	return func(opts *options) {
+		opts.minBackoff = min
+		opts.maxBackoff = max
+	}
+}
+
+// WithErrorHandler returns an option that sets a function called with errors
+// of the periodic flushes. If not used, such errors are dropped.
+func WithErrorHandler(handler func(error)) Option {
+	return func(opts *options) {
+		opts.errorHandler = handler
+	}
+}
+
+func applyOptions(opts []Option) *options {
+	options := &options{
+		flushInterval: DefaultFlushInterval,
+		sanitizer:     Sanitize,
+		percentiles:   DefaultPercentiles,
+		maxBufferSize: DefaultMaxBufferSize,
+		dialTimeout:   5 * time.Second,
+		writeTimeout:  DefaultWriteTimeout,
+		minBackoff:    100 * time.Millisecond,
+		maxBackoff:    30 * time.Second,
+		errorHandler:  func(error) {},
+	}
+	for _, o := range opts {
+		o(options)
+	}
+	if options.adapters.TagsSep == "" {
+		options.adapters.TagsSep = "."
+	}
+	if options.adapters.TagKVSep == "" {
+		options.adapters.TagKVSep = "_"
+	}
+	if options.maxBackoff < options.minBackoff {
+		options.maxBackoff = options.minBackoff
+	}
+	return options
+}
+
+// New creates a Factory writing metrics to a Graphite server at address, e.g. "localhost:2003".
+// The connection is established on the first flush and re-established when it fails.
+// Close must be called to write the last aggregated metrics and release the connection.
+func New(address string, opts ...Option) *Factory {
+	options := applyOptions(opts)
+	r := &reporter{
+		writer:   newWriter(address, options.dialTimeout, options.writeTimeout, options.minBackoff, options.maxBackoff, options.maxBufferSize),
+		now:      time.Now,
+		prefix:   options.prefix,
+		adapters: options.adapters,
+		tagged:   options.tagged,
+		sanitize: options.sanitizer,
+		suffixes: percentileSuffixes(options.percentiles),
+	}
+	return &Factory{
+		Factory: aggregate.New(r, aggregate.Options{
+			FlushInterval: options.flushInterval,
+			Percentiles:   options.percentiles,
+			Adapters:      options.adapters,
+			ErrorHandler:  options.errorHandler,
+		}),
+		reporter: r,
+	}
+}
+
+// DroppedFlushes returns how many flushes have been dropped because the server
+// was unreachable for longer than the buffer could hold.
+func (f *Factory) DroppedFlushes() int64 {
+	return f.reporter.writer.droppedBatches()
+}
+
+// Sanitize replaces the characters that cannot be used in a Graphite path component,
+// including whitespace and the ";" and "=" of the tags syntax, with "_".
+// Dots are kept, so that names like "ns.name" keep their hierarchy.
+func Sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '.', r == '-', r == '_', r == ':':
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphite
+
+import (
+	"bufio"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/adapters"
+)
+
+var _ metrics.Factory = new(Factory)
+
+// server is a Graphite stand-in collecting the received lines.
+type server struct {
+	sync.Mutex
+	listener net.Listener
+	lines    []string
+}
+
+func newServer(t *testing.T, address string) *server {
+	listener, err := net.Listen("tcp", address)
+	require.NoError(t, err)
+	s := &server{listener: listener}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.read(conn)
+		}
+	}()
+	return s
+}
+
+func (s *server) read(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		s.Lock()
+		s.lines = append(s.lines, scanner.Text())
+		s.Unlock()
+	}
+}
+
+func (s *server) waitForLines(t *testing.T, n int) []string {
+	for i := 0; i < 1000; i++ {
+		s.Lock()
+		if len(s.lines) >= n {
+			lines := append([]string(nil), s.lines...)
+			s.Unlock()
+			return lines
+		}
+		s.Unlock()
+		time.Sleep(time.Millisecond)
+	}
+	s.Lock()
+	defer s.Unlock()
+	require.FailNow(t, "timed out waiting for lines", "received %v", s.lines)
+	return nil
+}
+
+func (s *server) address() string {
+	return s.listener.Addr().String()
+}
+
+func fixedClock(f *Factory) {
+	f.reporter.now = func() time.Time { return time.Unix(1500000000, 0) }
+}
+
+func TestFactory(t *testing.T) {
+	s := newServer(t, "127.0.0.1:0")
+	defer s.listener.Close()
+
+	f := New(s.address(), WithPrefix("jaeger."), WithFlushInterval(0), WithPercentiles([]float64{50, 99.9}))
+	fixedClock(f)
+	ns := f.Namespace(metrics.NSOptions{Name: "query", Tags: map[string]string{"a": "b"}})
+	c := ns.Counter(metrics.Options{Name: "requests", Tags: map[string]string{"result": "ok"}})
+	c.Inc(3)
+	c.Inc(4)
+	ns.Gauge(metrics.Options{Name: "queue-length"}).Update(42)
+	timer := ns.Timer(metrics.TimerOptions{Name: "latency"})
+	timer.Record(2 * time.Millisecond)
+	timer.Record(4 * time.Millisecond)
+	ns.Histogram(metrics.HistogramOptions{Name: "size"})
+	require.NoError(t, f.Flush())
+
+	assert.Equal(t, []string{
+		"jaeger.query.requests.a_b.result_ok 7 1500000000",
+		"jaeger.query.queue-length.a_b 42 1500000000",
+		"jaeger.query.latency.a_b.count 2 1500000000",
+		"jaeger.query.latency.a_b.sum 6 1500000000",
+		"jaeger.query.latency.a_b.min 2 1500000000",
+		"jaeger.query.latency.a_b.max 4 1500000000",
+		"jaeger.query.latency.a_b.mean 3 1500000000",
+		"jaeger.query.latency.a_b.p50 2 1500000000",
+		"jaeger.query.latency.a_b.p99_9 4 1500000000",
+		"jaeger.query.size.a_b.count 0 1500000000",
+	}, s.waitForLines(t, 10))
+
+	// the values are reset after each flush, except for gauges
+	require.NoError(t, f.Close())
+	assert.Equal(t, []string{
+		"jaeger.query.requests.a_b.result_ok 0 1500000000",
+		"jaeger.query.queue-length.a_b 42 1500000000",
+		"jaeger.query.latency.a_b.count 0 1500000000",
+		"jaeger.query.size.a_b.count 0 1500000000",
+	}, s.waitForLines(t, 14)[10:])
+}
+
+func TestFactoryTaggedPaths(t *testing.T) {
+	s := newServer(t, "127.0.0.1:0")
+	defer s.listener.Close()
+
+	f := New(s.address(), WithFlushInterval(0), WithTaggedPaths(true), WithPercentiles(nil))
+	fixedClock(f)
+	ns := f.Namespace(metrics.NSOptions{Name: "query", Tags: map[string]string{"a": "b"}})
+	ns.Counter(metrics.Options{Name: "requests", Tags: map[string]string{"result": "ok;=x"}}).Inc(1)
+	ns.Histogram(metrics.HistogramOptions{Name: "size"}).Record(1.5)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, []string{
+		"query.requests;a=b;result=ok__x 1 1500000000",
+		"query.size.count;a=b 1 1500000000",
+		"query.size.sum;a=b 1.5 1500000000",
+		"query.size.min;a=b 1.5 1500000000",
+		"query.size.max;a=b 1.5 1500000000",
+		"query.size.mean;a=b 1.5 1500000000",
+	}, s.waitForLines(t, 6))
+}
+
+func TestFactoryAdaptersOptions(t *testing.T) {
+	s := newServer(t, "127.0.0.1:0")
+	defer s.listener.Close()
+
+	f := New(s.address(),
+		WithFlushInterval(0),
+		WithAdaptersOptions(adapters.Options{ScopeSep: "_", TagsSep: ".", TagKVSep: "-"}),
+		WithSanitizer(func(s string) string { return s }),
+	)
+	fixedClock(f)
+	f.Namespace(metrics.NSOptions{Name: "ns"}).Gauge(metrics.Options{
+		Name: "gauge",
+		Tags: map[string]string{"x": "y z"},
+	}).Update(1)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, []string{"ns_gauge.x-y z 1 1500000000"}, s.waitForLines(t, 1))
+}
+
+func TestFactoryFlushInterval(t *testing.T) {
+	s := newServer(t, "127.0.0.1:0")
+	defer s.listener.Close()
+
+	f := New(s.address(), WithFlushInterval(time.Millisecond))
+	defer f.Close()
+	f.Gauge(metrics.Options{Name: "gauge"}).Update(1)
+
+	assert.Contains(t, s.waitForLines(t, 1)[0], "gauge 1 ")
+}
+
+func TestFactoryReconnect(t *testing.T) {
+	// reserve an address and close the listener so that the first flush fails
+	s := newServer(t, "127.0.0.1:0")
+	address := s.address()
+	s.listener.Close()
+
+	f := New(address, WithFlushInterval(0), WithReconnectBackoff(time.Millisecond, time.Millisecond))
+	fixedClock(f)
+	c := f.Counter(metrics.Options{Name: "counter"})
+	c.Inc(1)
+	assert.Error(t, f.Flush())
+	c.Inc(2)
+	assert.Equal(t, errBackoff, f.Flush(), "reconnecting before the backoff elapses")
+
+	s = newServer(t, address)
+	defer s.listener.Close()
+	time.Sleep(2 * time.Millisecond)
+	c.Inc(3)
+	require.NoError(t, f.Flush())
+	assert.Equal(t, []string{
+		"counter 1 1500000000",
+		"counter 2 1500000000",
+		"counter 3 1500000000",
+	}, s.waitForLines(t, 3))
+	assert.EqualValues(t, 0, f.DroppedFlushes())
+	require.NoError(t, f.Close())
+}
+
+func TestFactoryMaxBufferSize(t *testing.T) {
+	s := newServer(t, "127.0.0.1:0")
+	address := s.address()
+	s.listener.Close()
+
+	f := New(address, WithFlushInterval(0), WithMaxBufferSize(50), WithReconnectBackoff(time.Hour, time.Hour))
+	fixedClock(f)
+	f.Gauge(metrics.Options{Name: "gauge"}).Update(1) // 19 bytes per flush
+	for i := 0; i < 5; i++ {
+		assert.Error(t, f.Flush())
+	}
+	assert.EqualValues(t, 3, f.DroppedFlushes())
+	assert.Equal(t, 38, f.reporter.writer.pendingSize)
+	assert.Error(t, f.Close())
+}
+
+func TestWriterWriteTimeout(t *testing.T) {
+	// a server that accepts connections but never reads from them
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			accepted <- conn
+		}
+	}()
+
+	w := newWriter(listener.Addr().String(), time.Second, 50*time.Millisecond, time.Millisecond, time.Millisecond, 1<<30)
+	// large enough to fill the socket buffers
+	batch := make([]byte, 64<<20)
+	err = w.write(batch)
+	require.Error(t, err)
+	netErr, ok := err.(net.Error)
+	require.True(t, ok, "expected a net.Error, got %v", err)
+	assert.True(t, netErr.Timeout())
+	assert.Nil(t, w.conn, "the connection is dropped")
+	assert.Equal(t, len(batch), w.pendingSize, "the batch is kept for the next write")
+	(<-accepted).Close()
+}
+
+func TestSanitize(t *testing.T) {
+	assert.Equal(t, "a.b-c_d:e_f_g_h_", Sanitize("a.b-c_d:e f;g=h\n"))
+}
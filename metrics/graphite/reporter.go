@@ -0,0 +1,123 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphite
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics/adapters"
+	"github.com/uber/jaeger-lib/metrics/internal/aggregate"
+	"github.com/uber/jaeger-lib/metrics/internal/percentile"
+)
+
+// reporter writes the aggregated metrics as lines of the Graphite plaintext protocol.
+type reporter struct {
+	writer   *writer
+	now      func() time.Time
+	prefix   string
+	adapters adapters.Options
+	tagged   bool
+	sanitize func(string) string
+	suffixes []string
+}
+
+// percentileSuffixes returns the path suffixes of the percentiles, e.g. ".p99".
+func percentileSuffixes(percentiles []float64) []string {
+	suffixes := make([]string, len(percentiles))
+	for i, p := range percentiles {
+		// a dot would add a level to the path, e.g. 99.9 is reported as ".p99_9"
+		suffixes[i] = "." + percentile.Name(p)
+	}
+	return suffixes
+}
+
+// Report writes the snapshots as a single batch of lines.
+func (r *reporter) Report(snapshots []aggregate.Snapshot) error {
+	timestamp := strconv.FormatInt(r.now().Unix(), 10)
+	var batch []byte
+	for i := range snapshots {
+		batch = r.appendLines(batch, &snapshots[i], timestamp)
+	}
+	return r.writer.write(batch)
+}
+
+// Close closes the connection.
+func (r *reporter) Close() error {
+	return r.writer.close()
+}
+
+func (r *reporter) appendLines(b []byte, s *aggregate.Snapshot, timestamp string) []byte {
+	path := r.path(s.Name, s.Tags)
+	if s.Type == aggregate.CounterType || s.Type == aggregate.GaugeType {
+		return appendLine(b, path(""), strconv.FormatInt(s.Value, 10), timestamp)
+	}
+	b = appendLine(b, path(".count"), strconv.FormatInt(s.Count, 10), timestamp)
+	if s.Count == 0 {
+		return b
+	}
+	b = appendLine(b, path(".sum"), formatFloat(s.Sum), timestamp)
+	b = appendLine(b, path(".min"), formatFloat(s.Min), timestamp)
+	b = appendLine(b, path(".max"), formatFloat(s.Max), timestamp)
+	b = appendLine(b, path(".mean"), formatFloat(s.Mean()), timestamp)
+	for i, v := range s.Percentiles {
+		b = appendLine(b, path(r.suffixes[i]), formatFloat(v), timestamp)
+	}
+	return b
+}
+
+// path returns the function building the path of a metric with an optional suffix,
+// e.g. ".p99", where the tags are either flattened with the adapters.Options
+// separators or appended as ";k=v".
+func (r *reporter) path(name string, tags map[string]string) func(suffix string) string {
+	name = r.prefix + r.sanitize(name)
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var suffix strings.Builder
+	for _, k := range keys {
+		if r.tagged {
+			suffix.WriteString(";" + r.sanitize(k) + "=" + r.sanitize(tags[k]))
+		} else {
+			suffix.WriteString(r.adapters.TagsSep + r.sanitize(k) + r.adapters.TagKVSep + r.sanitize(tags[k]))
+		}
+	}
+	tagsSuffix := suffix.String()
+	if r.tagged {
+		return func(suffix string) string {
+			return name + suffix + tagsSuffix
+		}
+	}
+	return func(suffix string) string {
+		return name + tagsSuffix + suffix
+	}
+}
+
+func appendLine(b []byte, path string, value string, timestamp string) []byte {
+	b = append(b, path...)
+	b = append(b, ' ')
+	b = append(b, value...)
+	b = append(b, ' ')
+	b = append(b, timestamp...)
+	return append(b, '\n')
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphite
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// errBackoff is returned while waiting to retry a failed connection.
+var errBackoff = errors.New("graphite: waiting to reconnect")
+
+// writer sends batches of lines over a TCP connection, reconnecting with an exponential
+// backoff when the connection fails. While disconnected, batches are buffered up to
+// maxBufferSize bytes, after which the oldest batches are dropped.
+type writer struct {
+	lock          sync.Mutex
+	address       string
+	dialTimeout   time.Duration
+	writeTimeout  time.Duration
+	minBackoff    time.Duration
+	maxBackoff    time.Duration
+	maxBufferSize int
+	now           func() time.Time
+
+	conn        net.Conn
+	backoff     time.Duration
+	nextDial    time.Time
+	pending     [][]byte
+	pendingSize int
+	dropped     int64
+}
+
+func newWriter(address string, dialTimeout, writeTimeout, minBackoff, maxBackoff time.Duration, maxBufferSize int) *writer {
+	return &writer{
+		address:       address,
+		dialTimeout:   dialTimeout,
+		writeTimeout:  writeTimeout,
+		minBackoff:    minBackoff,
+		maxBackoff:    maxBackoff,
+		maxBufferSize: maxBufferSize,
+		now:           time.Now,
+	}
+}
+
+// write buffers the batch and sends everything buffered so far if a connection
+// is available. A nil batch only retries sending the buffered batches.
+// Each batch must be written within the write timeout, otherwise the connection
+// is dropped and the batch is kept for the next write.
+func (w *writer) write(batch []byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if len(batch) > 0 {
+		w.pending = append(w.pending, batch)
+		w.pendingSize += len(batch)
+		for w.pendingSize > w.maxBufferSize && len(w.pending) > 0 {
+			w.pendingSize -= len(w.pending[0])
+			w.pending = w.pending[1:]
+			w.dropped++
+		}
+	}
+	if len(w.pending) == 0 {
+		return nil
+	}
+	if err := w.connect(); err != nil {
+		return err
+	}
+	for len(w.pending) > 0 {
+		if w.writeTimeout > 0 {
+			if err := w.conn.SetWriteDeadline(time.Now().Add(w.writeTimeout)); err != nil {
+				w.disconnect()
+				return err
+			}
+		}
+		if _, err := w.conn.Write(w.pending[0]); err != nil {
+			// the batch may have been partially written, but there is no way to tell
+			// how much of it the server has received, so the whole batch is resent
+			w.disconnect()
+			return err
+		}
+		w.pendingSize -= len(w.pending[0])
+		w.pending = w.pending[1:]
+	}
+	return nil
+}
+
+func (w *writer) connect() error {
+	if w.conn != nil {
+		return nil
+	}
+	if w.now().Before(w.nextDial) {
+		return errBackoff
+	}
+	conn, err := net.DialTimeout("tcp", w.address, w.dialTimeout)
+	if err != nil {
+		if w.backoff == 0 {
+			w.backoff = w.minBackoff
+		} else if w.backoff *= 2; w.backoff > w.maxBackoff {
+			w.backoff = w.maxBackoff
+		}
+		w.nextDial = w.now().Add(w.backoff)
+		return err
+	}
+	w.conn = conn
+	w.backoff = 0
+	return nil
+}
+
+func (w *writer) disconnect() {
+	w.conn.Close()
+	w.conn = nil
+	w.nextDial = time.Time{}
+}
+
+// droppedBatches returns the number of batches dropped because the buffer was full.
+func (w *writer) droppedBatches() int64 {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.dropped
+}
+
+func (w *writer) close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
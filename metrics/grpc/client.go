@@ -0,0 +1,104 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+// ClientInterceptors returns the unary and stream interceptors of a client recording,
+// in the namespace "grpc_client" of factory, the metrics described for ServerInterceptors,
+// with "started" counting the RPCs sent, and the latency of streams measured until
+// the last response is received, or the stream fails.
+//
+// They can be installed with grpc.WithChainUnaryInterceptor and grpc.WithChainStreamInterceptor.
+func ClientInterceptors(factory metrics.Factory) (grpc.UnaryClientInterceptor, grpc.StreamClientInterceptor) {
+	r := newRecorder(factory, "grpc_client")
+	return r.unaryClient, r.streamClient
+}
+
+func (r *recorder) unaryClient(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	m, done := r.start(Unary, method)
+	m.msgSent.Inc(1)
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err == nil {
+		m.msgReceived.Inc(1)
+	}
+	done(err)
+	return err
+}
+
+func (r *recorder) streamClient(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	m, done := r.start(streamType(desc.ClientStreams, desc.ServerStreams), method)
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+	return &clientStream{ClientStream: cs, metrics: m, serverStreams: desc.ServerStreams, done: done}, nil
+}
+
+// clientStream counts the messages of a stream, and records it as handled when
+// the last response is received.
+type clientStream struct {
+	grpc.ClientStream
+	metrics       *methodMetrics
+	serverStreams bool
+	done          func(error)
+}
+
+func (s *clientStream) SendMsg(msg interface{}) error {
+	err := s.ClientStream.SendMsg(msg)
+	if err == nil {
+		s.metrics.msgSent.Inc(1)
+	}
+	return err
+}
+
+func (s *clientStream) RecvMsg(msg interface{}) error {
+	err := s.ClientStream.RecvMsg(msg)
+	switch {
+	case err == nil:
+		s.metrics.msgReceived.Inc(1)
+		if !s.serverStreams {
+			// the single response of a unary or client streaming RPC
+			s.done(nil)
+		}
+	case err == io.EOF:
+		s.done(nil)
+	default:
+		s.done(err)
+	}
+	return err
+}
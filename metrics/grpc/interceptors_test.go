@@ -0,0 +1,145 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+)
+
+func tags(typ, method string, code ...codes.Code) map[string]string {
+	tags := map[string]string{"type": typ, "service": "grpc.health.v1.Health", "method": method}
+	if len(code) > 0 {
+		tags["code"] = code[0].String()
+	}
+	return tags
+}
+
+func TestInterceptors(t *testing.T) {
+	serverFactory := metricstest.NewFactory(0)
+	defer serverFactory.Stop()
+	clientFactory := metricstest.NewFactory(0)
+	defer clientFactory.Stop()
+
+	lis := bufconn.Listen(1 << 20)
+	unaryServer, streamServer := ServerInterceptors(serverFactory)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(unaryServer), grpc.ChainStreamInterceptor(streamServer))
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("svc", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	unaryClient, streamClient := ClientInterceptors(clientFactory)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(unaryClient),
+		grpc.WithChainStreamInterceptor(streamClient),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := healthpb.NewHealthClient(conn)
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "svc"})
+	require.NoError(t, err)
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	watch, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "svc"})
+	require.NoError(t, err)
+	resp, err := watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	healthServer.SetServingStatus("svc", healthpb.HealthCheckResponse_NOT_SERVING)
+	resp, err = watch.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+	cancel()
+	_, err = watch.Recv()
+	assert.Equal(t, codes.Canceled, status.Code(err))
+
+	watchCanceled := tags(ServerStream, "Watch", codes.Canceled)
+	for i := 0; i < 1000; i++ {
+		if counters, _ := serverFactory.Snapshot(); counters["grpc_server.handled|code=Canceled|method=Watch|service=grpc.health.v1.Health|type=server_stream"] > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	for _, f := range []struct {
+		factory   *metricstest.Factory
+		namespace string
+	}{
+		{serverFactory, "grpc_server."},
+		{clientFactory, "grpc_client."},
+	} {
+		f.factory.AssertCounterMetrics(t,
+			metricstest.ExpectedMetric{Name: f.namespace + "started", Tags: tags(Unary, "Check"), Value: 2},
+			metricstest.ExpectedMetric{Name: f.namespace + "handled", Tags: tags(Unary, "Check", codes.OK), Value: 1},
+			metricstest.ExpectedMetric{Name: f.namespace + "handled", Tags: tags(Unary, "Check", codes.NotFound), Value: 1},
+			metricstest.ExpectedMetric{Name: f.namespace + "started", Tags: tags(ServerStream, "Watch"), Value: 1},
+			metricstest.ExpectedMetric{Name: f.namespace + "handled", Tags: watchCanceled, Value: 1},
+		)
+		_, gauges := f.factory.Snapshot()
+		assert.Contains(t, gauges, f.namespace+"latency|code=NotFound|method=Check|service=grpc.health.v1.Health|type=unary.P99")
+		assert.Contains(t, gauges, f.namespace+"latency|code=Canceled|method=Watch|service=grpc.health.v1.Health|type=server_stream.P99")
+	}
+	serverFactory.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "grpc_server.msg_received", Tags: tags(Unary, "Check"), Value: 2},
+		metricstest.ExpectedMetric{Name: "grpc_server.msg_sent", Tags: tags(Unary, "Check"), Value: 1},
+		metricstest.ExpectedMetric{Name: "grpc_server.msg_received", Tags: tags(ServerStream, "Watch"), Value: 1},
+		metricstest.ExpectedMetric{Name: "grpc_server.msg_sent", Tags: tags(ServerStream, "Watch"), Value: 2},
+	)
+	clientFactory.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "grpc_client.msg_sent", Tags: tags(Unary, "Check"), Value: 2},
+		metricstest.ExpectedMetric{Name: "grpc_client.msg_received", Tags: tags(Unary, "Check"), Value: 1},
+		metricstest.ExpectedMetric{Name: "grpc_client.msg_sent", Tags: tags(ServerStream, "Watch"), Value: 1},
+		metricstest.ExpectedMetric{Name: "grpc_client.msg_received", Tags: tags(ServerStream, "Watch"), Value: 2},
+	)
+}
+
+func TestSplitMethod(t *testing.T) {
+	service, method := splitMethod("/package.Service/Method")
+	assert.Equal(t, "package.Service", service)
+	assert.Equal(t, "Method", method)
+	service, method = splitMethod("malformed")
+	assert.Equal(t, "unknown", service)
+	assert.Equal(t, "unknown", method)
+}
+
+func TestStreamType(t *testing.T) {
+	assert.Equal(t, Unary, streamType(false, false))
+	assert.Equal(t, ClientStream, streamType(true, false))
+	assert.Equal(t, ServerStream, streamType(false, true))
+	assert.Equal(t, BidiStream, streamType(true, true))
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grpc records metrics of gRPC servers and clients through a metrics.Factory,
+// with interceptors.
+package grpc
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+// The types of RPCs, values of the tag "type".
+const (
+	Unary        = "unary"
+	ClientStream = "client_stream"
+	ServerStream = "server_stream"
+	BidiStream   = "bidi_stream"
+)
+
+// recorder creates and caches the metrics of each RPC method and code.
+type recorder struct {
+	factory metrics.Factory
+	methods sync.Map // of *methodMetrics by type and full method
+	handled sync.Map // of *handledMetrics by type, full method and code
+}
+
+type methodMetrics struct {
+	tags        map[string]string
+	started     metrics.Counter
+	msgReceived metrics.Counter
+	msgSent     metrics.Counter
+}
+
+type handledMetrics struct {
+	handled metrics.Counter
+	latency metrics.Timer
+}
+
+func newRecorder(factory metrics.Factory, namespace string) *recorder {
+	return &recorder{factory: factory.Namespace(metrics.NSOptions{Name: namespace})}
+}
+
+func (r *recorder) methodMetrics(typ, fullMethod string) *methodMetrics {
+	key := typ + fullMethod
+	if m, ok := r.methods.Load(key); ok {
+		return m.(*methodMetrics)
+	}
+	service, method := splitMethod(fullMethod)
+	tags := map[string]string{"type": typ, "service": service, "method": method}
+	m, _ := r.methods.LoadOrStore(key, &methodMetrics{
+		tags:        tags,
+		started:     r.factory.Counter(metrics.Options{Name: "started", Tags: tags}),
+		msgReceived: r.factory.Counter(metrics.Options{Name: "msg_received", Tags: tags}),
+		msgSent:     r.factory.Counter(metrics.Options{Name: "msg_sent", Tags: tags}),
+	})
+	return m.(*methodMetrics)
+}
+
+// start counts an RPC as started and returns the function to call when it is handled.
+func (r *recorder) start(typ, fullMethod string) (*methodMetrics, func(err error)) {
+	m := r.methodMetrics(typ, fullMethod)
+	m.started.Inc(1)
+	start := time.Now()
+	var once sync.Once
+	return m, func(err error) {
+		once.Do(func() {
+			h := r.handledMetrics(m, typ, fullMethod, status.Code(err))
+			h.handled.Inc(1)
+			h.latency.Record(time.Since(start))
+		})
+	}
+}
+
+func (r *recorder) handledMetrics(m *methodMetrics, typ, fullMethod string, code codes.Code) *handledMetrics {
+	key := typ + fullMethod + "|" + code.String()
+	if h, ok := r.handled.Load(key); ok {
+		return h.(*handledMetrics)
+	}
+	tags := map[string]string{"code": code.String()}
+	for k, v := range m.tags {
+		tags[k] = v
+	}
+	h, _ := r.handled.LoadOrStore(key, &handledMetrics{
+		handled: r.factory.Counter(metrics.Options{Name: "handled", Tags: tags}),
+		latency: r.factory.Timer(metrics.TimerOptions{Name: "latency", Tags: tags}),
+	})
+	return h.(*handledMetrics)
+}
+
+// splitMethod splits "/package.Service/Method" into the service and the method.
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", "unknown"
+}
+
+func streamType(clientStreams, serverStreams bool) string {
+	switch {
+	case clientStreams && serverStreams:
+		return BidiStream
+	case clientStreams:
+		return ClientStream
+	case serverStreams:
+		return ServerStream
+	}
+	return Unary
+}
+
+func serverStreamType(info *grpc.StreamServerInfo) string {
+	return streamType(info.IsClientStream, info.IsServerStream)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+// ServerInterceptors returns the unary and stream interceptors of a server recording,
+// in the namespace "grpc_server" of factory, tagged with the type of RPC, e.g. "unary",
+// the service, e.g. "package.Service", and the method:
+//
+//   - A Counter "started" of the RPCs received.
+//   - A Counter "handled" of the RPCs completed, also tagged with their status code, e.g. "OK".
+//   - A Timer "latency", tagged like "handled", until the handler returns.
+//   - Counters "msg_received" and "msg_sent" of the messages of the RPCs.
+//
+// They can be installed with grpc.ChainUnaryInterceptor and grpc.ChainStreamInterceptor.
+func ServerInterceptors(factory metrics.Factory) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	r := newRecorder(factory, "grpc_server")
+	return r.unaryServer, r.streamServer
+}
+
+func (r *recorder) unaryServer(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	m, done := r.start(Unary, info.FullMethod)
+	m.msgReceived.Inc(1)
+	resp, err := handler(ctx, req)
+	if err == nil {
+		m.msgSent.Inc(1)
+	}
+	done(err)
+	return resp, err
+}
+
+func (r *recorder) streamServer(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	m, done := r.start(serverStreamType(info), info.FullMethod)
+	err := handler(srv, &serverStream{ServerStream: ss, metrics: m})
+	done(err)
+	return err
+}
+
+// serverStream counts the messages of a stream.
+type serverStream struct {
+	grpc.ServerStream
+	metrics *methodMetrics
+}
+
+func (s *serverStream) SendMsg(msg interface{}) error {
+	err := s.ServerStream.SendMsg(msg)
+	if err == nil {
+		s.metrics.msgSent.Inc(1)
+	}
+	return err
+}
+
+func (s *serverStream) RecvMsg(msg interface{}) error {
+	err := s.ServerStream.RecvMsg(msg)
+	if err == nil {
+		s.metrics.msgReceived.Inc(1)
+	}
+	return err
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+// NewTransport returns an http.RoundTripper sending the requests with next, or
+// http.DefaultTransport if nil, and recording, in the namespace "http_client" of factory:
+//
+//   - A Counter "requests" tagged with the method, the route and the status class, e.g. "2xx",
+//     or "error" if no response was received.
+//   - A Counter "errors" with the same tags, counting the requests without a response
+//     or answered with a 5xx status.
+//   - A Timer "latency" with the same tags, until the response headers are received.
+//   - Histograms "request_size" of the bodies of known length, and "response_size" of
+//     the bodies read up to their end or closed, in bytes, tagged with the method and
+//     the route, with SizeBuckets. The bodies of 101 Switching Protocols responses are
+//     the upgraded connections, they are not wrapped and their size is not recorded.
+//   - A Gauge "in_flight" with the number of requests waiting for their response headers.
+func NewTransport(factory metrics.Factory, next http.RoundTripper, opts ...Option) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{
+		recorder: newRecorder(factory.Namespace(metrics.NSOptions{Name: "http_client"}), opts),
+		next:     next,
+	}
+}
+
+type transport struct {
+	*recorder
+	next http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.addInFlight(1)
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	latency := time.Since(start)
+	t.addInFlight(-1)
+
+	tags := t.tags(req)
+	sizes := t.sizeMetrics(tags)
+	if req.ContentLength >= 0 {
+		sizes.requestSize.Record(float64(req.ContentLength))
+	}
+	if err != nil {
+		t.observe(tags, 0, true, latency)
+		return resp, err
+	}
+	t.observe(tags, resp.StatusCode, resp.StatusCode >= 500, latency)
+	if resp.StatusCode == http.StatusSwitchingProtocols {
+		// the body is the upgraded connection, an io.ReadWriteCloser, not a response
+		return resp, nil
+	}
+	resp.Body = &countingReader{
+		ReadCloser: resp.Body,
+		done: func(n int64) {
+			sizes.responseSize.Record(float64(n))
+		},
+	}
+	return resp, nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+)
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("created"))
+	}))
+	defer server.Close()
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	client := &http.Client{Transport: NewTransport(f, nil, WithRoute(func(r *http.Request) string {
+		return strings.Split(r.URL.Path, "/")[1]
+	}))}
+
+	resp, err := client.Post(server.URL+"/items/1", "text/plain", strings.NewReader("hello"))
+	require.NoError(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "created", string(body))
+	require.NoError(t, resp.Body.Close())
+	resp, err = client.Get(server.URL + "/fail")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	server.Close()
+	_, err = client.Get(server.URL + "/items/2")
+	require.Error(t, err)
+
+	ok := map[string]string{"method": "POST", "route": "items", "status": "2xx"}
+	failed := map[string]string{"method": "GET", "route": "fail", "status": "5xx"}
+	unreachable := map[string]string{"method": "GET", "route": "items", "status": "error"}
+	f.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "http_client.requests", Tags: ok, Value: 1},
+		metricstest.ExpectedMetric{Name: "http_client.errors", Tags: ok, Value: 0},
+		metricstest.ExpectedMetric{Name: "http_client.requests", Tags: failed, Value: 1},
+		metricstest.ExpectedMetric{Name: "http_client.errors", Tags: failed, Value: 1},
+		metricstest.ExpectedMetric{Name: "http_client.requests", Tags: unreachable, Value: 1},
+		metricstest.ExpectedMetric{Name: "http_client.errors", Tags: unreachable, Value: 1},
+	)
+	f.AssertGaugeMetrics(t,
+		metricstest.ExpectedMetric{Name: "http_client.in_flight", Value: 0},
+		metricstest.ExpectedMetric{Name: "http_client.request_size|method=POST|route=items.P50", Value: 5},
+		metricstest.ExpectedMetric{Name: "http_client.response_size|method=POST|route=items.P50", Value: 7},
+		metricstest.ExpectedMetric{Name: "http_client.response_size|method=GET|route=fail.P50", Value: 0},
+	)
+}
+
+func TestTransportUpgrade(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Connection", "Upgrade")
+		w.Header().Set("Upgrade", "echo")
+		w.WriteHeader(http.StatusSwitchingProtocols)
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		defer conn.Close()
+		line, err := buf.ReadString('\n')
+		require.NoError(t, err)
+		buf.WriteString(line)
+		buf.Flush()
+	}))
+	defer server.Close()
+
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	client := &http.Client{Transport: NewTransport(f, nil)}
+	req, err := http.NewRequest("GET", server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "echo")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+	conn, ok := resp.Body.(io.ReadWriteCloser)
+	require.True(t, ok, "the upgraded connection is writable")
+	_, err = conn.Write([]byte("hello\n"))
+	require.NoError(t, err)
+	echo, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", echo)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package http records RED metrics of net/http servers and clients through a metrics.Factory.
+package http
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+// SizeBuckets are the buckets, in bytes, of the Histograms of the request and response sizes.
+var SizeBuckets = []float64{64, 256, 1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20}
+
+type options struct {
+	route func(*http.Request) string
+}
+
+// Option is a function that sets some option for NewHandler and NewTransport.
+type Option func(*options)
+
+// WithRoute returns an option that adds a tag "route" to the metrics of each request,
+// whose value is returned by route. It must return values from a small set, such as the
+// patterns of the routes, not the paths, lest the backends hold a series per path.
+// For a handler, route is called after the request is served, so that it can return
+// the http.Request Pattern set by http.ServeMux. If not used, there is no "route" tag.
+func WithRoute(route func(r *http.Request) string) Option {
+	return func(opts *options) {
+		opts.route = route
+	}
+}
+
+// recorder creates and caches the metrics of each set of tags.
+type recorder struct {
+	inFlight      int64 // first for 64-bit alignment of atomic operations
+	factory       metrics.Factory
+	route         func(*http.Request) string
+	inFlightGauge metrics.Gauge
+	requests      sync.Map // of *requestMetrics by method, route and status
+	sizes         sync.Map // of *sizeMetrics by method and route
+}
+
+type requestMetrics struct {
+	requests metrics.Counter
+	errors   metrics.Counter
+	latency  metrics.Timer
+}
+
+type sizeMetrics struct {
+	requestSize  metrics.Histogram
+	responseSize metrics.Histogram
+}
+
+func newRecorder(factory metrics.Factory, opts []Option) *recorder {
+	options := &options{}
+	for _, o := range opts {
+		o(options)
+	}
+	return &recorder{
+		factory:       factory,
+		route:         options.route,
+		inFlightGauge: factory.Gauge(metrics.Options{Name: "in_flight"}),
+	}
+}
+
+func (r *recorder) addInFlight(delta int64) {
+	r.inFlightGauge.Update(atomic.AddInt64(&r.inFlight, delta))
+}
+
+// tags returns the method and route tags of a request.
+func (r *recorder) tags(req *http.Request) map[string]string {
+	tags := map[string]string{"method": method(req.Method)}
+	if r.route != nil {
+		tags["route"] = r.route(req)
+	}
+	return tags
+}
+
+// observe records a request whose response had the status, or failed if status is 0,
+// with its failure counted as an error or not.
+func (r *recorder) observe(tags map[string]string, status int, failed bool, latency time.Duration) {
+	class := statusClass(status)
+	key := tags["method"] + "|" + tags["route"] + "|" + class
+	m, ok := r.requests.Load(key)
+	if !ok {
+		statusTags := map[string]string{"status": class}
+		for k, v := range tags {
+			statusTags[k] = v
+		}
+		m, _ = r.requests.LoadOrStore(key, &requestMetrics{
+			requests: r.factory.Counter(metrics.Options{Name: "requests", Tags: statusTags}),
+			errors:   r.factory.Counter(metrics.Options{Name: "errors", Tags: statusTags}),
+			latency:  r.factory.Timer(metrics.TimerOptions{Name: "latency", Tags: statusTags}),
+		})
+	}
+	rm := m.(*requestMetrics)
+	rm.requests.Inc(1)
+	if failed {
+		rm.errors.Inc(1)
+	}
+	rm.latency.Record(latency)
+}
+
+func (r *recorder) sizeMetrics(tags map[string]string) *sizeMetrics {
+	key := tags["method"] + "|" + tags["route"]
+	m, ok := r.sizes.Load(key)
+	if !ok {
+		m, _ = r.sizes.LoadOrStore(key, &sizeMetrics{
+			requestSize:  r.factory.Histogram(metrics.HistogramOptions{Name: "request_size", Tags: tags, Buckets: SizeBuckets}),
+			responseSize: r.factory.Histogram(metrics.HistogramOptions{Name: "response_size", Tags: tags, Buckets: SizeBuckets}),
+		})
+	}
+	return m.(*sizeMetrics)
+}
+
+// method returns the method of a request, or "other" for non-standard methods,
+// which would otherwise let clients create series at will.
+func method(m string) string {
+	switch m {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return m
+	}
+	return "other"
+}
+
+// statusClass returns the class of a status code, e.g. "2xx", or "error" for 0,
+// when no response was received.
+func statusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "error"
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
+
+// countingReader counts the bytes read from a body, and calls done once with the count
+// at the end of the body or when it is closed.
+type countingReader struct {
+	io.ReadCloser
+	n    int64
+	done func(n int64)
+	once sync.Once
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.n += int64(n)
+	if err == io.EOF {
+		r.finish()
+	}
+	return n, err
+}
+
+func (r *countingReader) Close() error {
+	r.finish()
+	return r.ReadCloser.Close()
+}
+
+func (r *countingReader) finish() {
+	if r.done != nil {
+		r.once.Do(func() {
+			r.done(r.n)
+		})
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+// NewHandler returns a handler serving the requests with next and recording,
+// in the namespace "http_server" of factory:
+//
+//   - A Counter "requests" tagged with the method, the route and the status class, e.g. "2xx".
+//   - A Counter "errors" with the same tags, counting the requests answered with a 5xx status.
+//   - A Timer "latency" with the same tags, until the handler returns.
+//   - Histograms "request_size" and "response_size" of the bodies in bytes, tagged with
+//     the method and the route, with SizeBuckets.
+//   - A Gauge "in_flight" with the number of requests being served.
+func NewHandler(factory metrics.Factory, next http.Handler, opts ...Option) http.Handler {
+	return &handler{
+		recorder: newRecorder(factory.Namespace(metrics.NSOptions{Name: "http_server"}), opts),
+		next:     next,
+	}
+}
+
+type handler struct {
+	*recorder
+	next http.Handler
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.addInFlight(1)
+	defer h.addInFlight(-1)
+	start := time.Now()
+	var body *countingReader
+	if r.Body != nil {
+		body = &countingReader{ReadCloser: r.Body}
+		r.Body = body
+	}
+	rw := &responseWriter{ResponseWriter: w}
+
+	h.next.ServeHTTP(rw.wrap(), r)
+
+	latency := time.Since(start)
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	tags := h.tags(r)
+	h.observe(tags, rw.status, rw.status >= 500, latency)
+	sizes := h.sizeMetrics(tags)
+	if body != nil {
+		sizes.requestSize.Record(float64(body.n))
+	} else {
+		sizes.requestSize.Record(0)
+	}
+	sizes.responseSize.Record(float64(rw.written))
+}
+
+// responseWriter records the status and the size of a response.
+type responseWriter struct {
+	http.ResponseWriter
+	status  int
+	written int64
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.written += int64(n)
+	return n, err
+}
+
+// Unwrap returns the wrapped http.ResponseWriter, for http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// wrap returns w with the optional interfaces of the wrapped http.ResponseWriter among
+// http.Flusher, http.Hijacker, http.Pusher and io.ReaderFrom, so that the handlers can
+// still detect them with type assertions.
+func (w *responseWriter) wrap() http.ResponseWriter {
+	const (
+		isFlusher = 1 << iota
+		isHijacker
+		isPusher
+		isReaderFrom
+	)
+	var kind int
+	if _, ok := w.ResponseWriter.(http.Flusher); ok {
+		kind |= isFlusher
+	}
+	if _, ok := w.ResponseWriter.(http.Hijacker); ok {
+		kind |= isHijacker
+	}
+	if _, ok := w.ResponseWriter.(http.Pusher); ok {
+		kind |= isPusher
+	}
+	if _, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		kind |= isReaderFrom
+	}
+	f, h, p, rf := flusher{w}, hijacker{w}, pusher{w}, readerFrom{w}
+	switch kind {
+	case isFlusher:
+		return struct {
+			*responseWriter
+			flusher
+		}{w, f}
+	case isHijacker:
+		return struct {
+			*responseWriter
+			hijacker
+		}{w, h}
+	case isFlusher | isHijacker:
+		return struct {
+			*responseWriter
+			flusher
+			hijacker
+		}{w, f, h}
+	case isPusher:
+		return struct {
+			*responseWriter
+			pusher
+		}{w, p}
+	case isFlusher | isPusher:
+		return struct {
+			*responseWriter
+			flusher
+			pusher
+		}{w, f, p}
+	case isHijacker | isPusher:
+		return struct {
+			*responseWriter
+			hijacker
+			pusher
+		}{w, h, p}
+	case isFlusher | isHijacker | isPusher:
+		return struct {
+			*responseWriter
+			flusher
+			hijacker
+			pusher
+		}{w, f, h, p}
+	case isReaderFrom:
+		return struct {
+			*responseWriter
+			readerFrom
+		}{w, rf}
+	case isFlusher | isReaderFrom:
+		return struct {
+			*responseWriter
+			flusher
+			readerFrom
+		}{w, f, rf}
+	case isHijacker | isReaderFrom:
+		return struct {
+			*responseWriter
+			hijacker
+			readerFrom
+		}{w, h, rf}
+	case isFlusher | isHijacker | isReaderFrom:
+		return struct {
+			*responseWriter
+			flusher
+			hijacker
+			readerFrom
+		}{w, f, h, rf}
+	case isPusher | isReaderFrom:
+		return struct {
+			*responseWriter
+			pusher
+			readerFrom
+		}{w, p, rf}
+	case isFlusher | isPusher | isReaderFrom:
+		return struct {
+			*responseWriter
+			flusher
+			pusher
+			readerFrom
+		}{w, f, p, rf}
+	case isHijacker | isPusher | isReaderFrom:
+		return struct {
+			*responseWriter
+			hijacker
+			pusher
+			readerFrom
+		}{w, h, p, rf}
+	case isFlusher | isHijacker | isPusher | isReaderFrom:
+		return struct {
+			*responseWriter
+			flusher
+			hijacker
+			pusher
+			readerFrom
+		}{w, f, h, p, rf}
+	}
+	return w
+}
+
+type flusher struct{ w *responseWriter }
+
+func (f flusher) Flush() {
+	if f.w.status == 0 {
+		f.w.status = http.StatusOK
+	}
+	f.w.ResponseWriter.(http.Flusher).Flush()
+}
+
+type hijacker struct{ w *responseWriter }
+
+func (h hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return h.w.ResponseWriter.(http.Hijacker).Hijack()
+}
+
+type pusher struct{ w *responseWriter }
+
+func (p pusher) Push(target string, opts *http.PushOptions) error {
+	return p.w.ResponseWriter.(http.Pusher).Push(target, opts)
+}
+
+// readerFrom keeps the optimizations of the wrapped io.ReaderFrom, e.g. sendfile,
+// while counting the bytes written.
+type readerFrom struct{ w *responseWriter }
+
+func (rf readerFrom) ReadFrom(r io.Reader) (int64, error) {
+	if rf.w.status == 0 {
+		rf.w.status = http.StatusOK
+	}
+	n, err := rf.w.ResponseWriter.(io.ReaderFrom).ReadFrom(r)
+	rf.w.written += n
+	return n, err
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+)
+
+func TestHandler(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /items/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, gauges := f.Snapshot()
+		assert.EqualValues(t, 1, gauges["http_server.in_flight"])
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(body))
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("created"))
+	})
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusServiceUnavailable)
+	})
+	server := httptest.NewServer(NewHandler(f, mux, WithRoute(func(r *http.Request) string {
+		if r.Pattern == "" {
+			return "unknown"
+		}
+		return r.Pattern
+	})))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/items/1", "text/plain", strings.NewReader("hello"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	for _, path := range []string{"/fail", "/fail", "/missing"} {
+		resp, err = http.Get(server.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	req, err := http.NewRequest("FOO", server.URL+"/fail", nil)
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	created := map[string]string{"method": "POST", "route": "POST /items/{id}", "status": "2xx"}
+	failed := map[string]string{"method": "GET", "route": "/fail", "status": "5xx"}
+	f.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "http_server.requests", Tags: created, Value: 1},
+		metricstest.ExpectedMetric{Name: "http_server.errors", Tags: created, Value: 0},
+		metricstest.ExpectedMetric{Name: "http_server.requests", Tags: failed, Value: 2},
+		metricstest.ExpectedMetric{Name: "http_server.errors", Tags: failed, Value: 2},
+		metricstest.ExpectedMetric{
+			Name:  "http_server.requests",
+			Tags:  map[string]string{"method": "GET", "route": "unknown", "status": "4xx"},
+			Value: 1,
+		},
+		metricstest.ExpectedMetric{
+			Name:  "http_server.requests",
+			Tags:  map[string]string{"method": "other", "route": "/fail", "status": "5xx"},
+			Value: 1,
+		},
+	)
+	f.AssertGaugeMetrics(t,
+		metricstest.ExpectedMetric{Name: "http_server.in_flight", Value: 0},
+		metricstest.ExpectedMetric{
+			Name:  "http_server.request_size|method=POST|route=POST /items/{id}.P50",
+			Value: 5,
+		},
+		metricstest.ExpectedMetric{
+			Name:  "http_server.response_size|method=POST|route=POST /items/{id}.P50",
+			Value: 7,
+		},
+	)
+	_, gauges := f.Snapshot()
+	assert.Contains(t, gauges, "http_server.latency|method=POST|route=POST /items/{id}|status=2xx.P99")
+}
+
+func TestHandlerWithoutRoute(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	h := NewHandler(f, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.(http.Flusher).Flush()
+		assert.NotNil(t, w.(interface{ Unwrap() http.ResponseWriter }).Unwrap())
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/anything", nil))
+	f.AssertCounterMetrics(t, metricstest.ExpectedMetric{
+		Name:  "http_server.requests",
+		Tags:  map[string]string{"method": "GET", "status": "2xx"},
+		Value: 1,
+	})
+}
+
+func TestHandlerOptionalInterfaces(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/copy", func(w http.ResponseWriter, r *http.Request) {
+		_, isPusher := w.(http.Pusher)
+		assert.False(t, isPusher, "HTTP/1 writers are not pushers")
+		_, isReaderFrom := w.(io.ReaderFrom)
+		assert.True(t, isReaderFrom)
+		io.Copy(w, strings.NewReader("copied"))
+	})
+	mux.HandleFunc("/hijack", func(w http.ResponseWriter, r *http.Request) {
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		buf.WriteString("HTTP/1.1 200 OK\r\nContent-Length: 0\r\nConnection: close\r\n\r\n")
+		buf.Flush()
+		conn.Close()
+	})
+	server := httptest.NewServer(NewHandler(f, mux))
+	defer server.Close()
+
+	for _, path := range []string{"/copy", "/hijack"} {
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	f.AssertGaugeMetrics(t, metricstest.ExpectedMetric{
+		Name:  "http_server.response_size|method=GET.P99",
+		Value: 6,
+	})
+
+	h := NewHandler(f, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, isFlusher := w.(http.Flusher)
+		assert.False(t, isFlusher, "the wrapped writer is not a flusher")
+	}))
+	h.ServeHTTP(struct{ http.ResponseWriter }{httptest.NewRecorder()}, httptest.NewRequest("GET", "/", nil))
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdb
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics/adapters"
+	"github.com/uber/jaeger-lib/metrics/internal/aggregate"
+	"github.com/uber/jaeger-lib/metrics/statsd"
+)
+
+const (
+	// DefaultFlushInterval is how often the aggregated metrics are written.
+	DefaultFlushInterval = 10 * time.Second
+
+	// DefaultBatchSize is the maximum number of points written per HTTP request.
+	DefaultBatchSize = 5000
+)
+
+// DefaultPercentiles are the percentiles reported for Timers and Histograms.
+var DefaultPercentiles = []float64{50, 95, 99}
+
+// Factory implements metrics.Factory by aggregating metrics locally and writing them
+// to InfluxDB in the line protocol on each flush interval. Tags are written as Influx tags.
+//
+// Counters report the sum of the increments during the interval and Gauges the last value,
+// both in the "value" field. Timers and Histograms report the count, sum, min, max and mean
+// of the values recorded during the interval, as well as percentiles, e.g. "p99", as fields.
+// Timers are in milliseconds.
+type Factory struct {
+	*aggregate.Factory
+	reporter *reporter
+}
+
+type options struct {
+	flushInterval time.Duration
+	tags          map[string]string
+	scopeSep      string
+	percentiles   []float64
+	token         string
+	batchSize     int
+	httpClient    *http.Client
+	maxPacketSize int
+	errorHandler  func(error)
+}
+
+// Option is a function that sets some option for the Factory constructor.
+type Option func(*options)
+
+// WithFlushInterval returns an option that sets how often the aggregated metrics
+// are written. If not used, we fallback to DefaultFlushInterval.
+// A non-positive interval disables the periodic flushes, Flush must be called instead.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.flushInterval = interval
+	}
+}
+
+// WithTags returns an option that sets constant tags added to all points,
+// e.g. the host name. Tags of the metrics and their namespaces take precedence.
+func WithTags(tags map[string]string) Option {
+	return func(opts *options) {
+		opts.tags = tags
+	}
+}
+
+// WithScopeSeparator returns an option that sets the separator between namespace
+// names and metric names in measurement names. If not used, we fallback to ".".
+func WithScopeSeparator(separator string) Option {
+	return func(opts *options) {
+		opts.scopeSep = separator
+	}
+}
+
+// WithPercentiles returns an option that sets the percentiles, between 0 and 100,
+// reported for Timers and Histograms. If not used, we fallback to DefaultPercentiles.
+func WithPercentiles(percentiles []float64) Option {
+	return func(opts *options) {
+		opts.percentiles = percentiles
+	}
+}
+
+// WithToken returns an option that sets the API token used to authenticate HTTP writes.
+func WithToken(token string) Option {
+	return func(opts *options) {
+		opts.token = token
+	}
+}
+
+// WithBatchSize returns an option that sets the maximum number of points written per
+// HTTP request. If not used, we fallback to DefaultBatchSize.
+func WithBatchSize(size int) Option {
+	return func(opts *options) {
+		opts.batchSize = size
+	}
+}
+
+// WithHTTPClient returns an option that sets the client used for HTTP writes.
+// If not used, we fallback to a client with a 10s timeout.
+func WithHTTPClient(client *http.Client) Option {
+	return func(opts *options) {
+		opts.httpClient = client
+	}
+}
+
+// WithMaxPacketSize returns an option that sets the maximum size of a UDP datagram.
+// If not used, we fallback to statsd.DefaultUDPPacketSize.
+func WithMaxPacketSize(size int) Option {
+	return func(opts *options) {
+		opts.maxPacketSize = size
+	}
+}
+
+// WithErrorHandler returns an option that sets a function called with errors
+// of the periodic flushes. If not used, such errors are dropped.
+func WithErrorHandler(handler func(error)) Option {
+	return func(opts *options) {
+		opts.errorHandler = handler
+	}
+}
+
+func applyOptions(opts []Option) *options {
+	options := &options{
+		flushInterval: DefaultFlushInterval,
+		percentiles:   DefaultPercentiles,
+		batchSize:     DefaultBatchSize,
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+		maxPacketSize: statsd.DefaultUDPPacketSize,
+		errorHandler:  func(error) {},
+	}
+	for _, o := range opts {
+		o(options)
+	}
+	if options.batchSize <= 0 {
+		options.batchSize = DefaultBatchSize
+	}
+	return options
+}
+
+// NewHTTP creates a Factory writing metrics to the bucket of the InfluxDB 2.x server
+// at serverURL, e.g. "http://localhost:8086", with the /api/v2/write endpoint.
+// Close must be called to write the last aggregated metrics.
+func NewHTTP(serverURL, org, bucket string, opts ...Option) (*Factory, error) {
+	options := applyOptions(opts)
+	w, err := newHTTPWriter(serverURL, org, bucket, options.token, options.batchSize, options.httpClient)
+	if err != nil {
+		return nil, err
+	}
+	return newFactory(w, options), nil
+}
+
+// NewUDP creates a Factory writing metrics to the UDP listener of an InfluxDB server
+// at address, e.g. "localhost:8089". Close must be called to write the last aggregated
+// metrics and release the connection.
+func NewUDP(address string, opts ...Option) (*Factory, error) {
+	options := applyOptions(opts)
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, err
+	}
+	sender := statsd.NewSender(conn, options.maxPacketSize, 0, options.errorHandler)
+	return newFactory(&udpWriter{sender: sender}, options), nil
+}
+
+func newFactory(w writer, options *options) *Factory {
+	r := &reporter{
+		writer: w,
+		now:    time.Now,
+		tags:   options.tags,
+		fields: percentileFields(options.percentiles),
+	}
+	return &Factory{
+		Factory: aggregate.New(r, aggregate.Options{
+			FlushInterval: options.flushInterval,
+			Percentiles:   options.percentiles,
+			Adapters:      adapters.Options{ScopeSep: options.scopeSep},
+			ErrorHandler:  options.errorHandler,
+		}),
+		reporter: r,
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdb
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+var _ metrics.Factory = new(Factory)
+
+// influxServer is an InfluxDB stand-in recording the write requests.
+type influxServer struct {
+	sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   []string
+}
+
+func (s *influxServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	s.Lock()
+	defer s.Unlock()
+	s.requests = append(s.requests, r)
+	s.bodies = append(s.bodies, string(body))
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+		w.Write([]byte(`{"code":"invalid","message":"bad point"}`))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *influxServer) lines() []string {
+	s.Lock()
+	defer s.Unlock()
+	var lines []string
+	for _, body := range s.bodies {
+		lines = append(lines, strings.Split(body, "\n")...)
+	}
+	return lines
+}
+
+func fixedClock(f *Factory) {
+	f.reporter.now = func() time.Time { return time.Unix(1500000000, 0) }
+}
+
+func TestHTTPFactory(t *testing.T) {
+	s := &influxServer{}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	f, err := NewHTTP(server.URL+"/", "my-org", "my-bucket",
+		WithToken("secret"),
+		WithFlushInterval(0),
+		WithTags(map[string]string{"host": "h1", "a": "const"}),
+		WithPercentiles([]float64{50, 99.9}),
+	)
+	require.NoError(t, err)
+	fixedClock(f)
+
+	ns := f.Namespace(metrics.NSOptions{Name: "query", Tags: map[string]string{"a": "b"}})
+	c := ns.Counter(metrics.Options{Name: "requests", Tags: map[string]string{"result": "ok"}})
+	c.Inc(3)
+	c.Inc(4)
+	ns.Gauge(metrics.Options{Name: "queue-length"}).Update(42)
+	timer := ns.Timer(metrics.TimerOptions{Name: "latency"})
+	timer.Record(2 * time.Millisecond)
+	timer.Record(4 * time.Millisecond)
+	ns.Histogram(metrics.HistogramOptions{Name: "size"})
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, []string{
+		"query.requests,a=b,host=h1,result=ok value=7i 1500000000000000000",
+		"query.queue-length,a=b,host=h1 value=42i 1500000000000000000",
+		"query.latency,a=b,host=h1 count=2i,sum=6,min=2,max=4,mean=3,p50=2,p99_9=4 1500000000000000000",
+		"query.size,a=b,host=h1 count=0i 1500000000000000000",
+	}, s.lines())
+
+	require.Len(t, s.requests, 1)
+	req := s.requests[0]
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "/api/v2/write", req.URL.Path)
+	assert.Equal(t, "my-org", req.URL.Query().Get("org"))
+	assert.Equal(t, "my-bucket", req.URL.Query().Get("bucket"))
+	assert.Equal(t, "ns", req.URL.Query().Get("precision"))
+	assert.Equal(t, "Token secret", req.Header.Get("Authorization"))
+}
+
+func TestHTTPFactoryBatchSize(t *testing.T) {
+	s := &influxServer{}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	f, err := NewHTTP(server.URL, "org", "bucket", WithFlushInterval(0), WithBatchSize(2))
+	require.NoError(t, err)
+	fixedClock(f)
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		f.Gauge(metrics.Options{Name: name}).Update(1)
+	}
+	require.NoError(t, f.Flush())
+
+	assert.Len(t, s.requests, 3)
+	assert.Len(t, s.lines(), 5)
+	assert.Empty(t, s.requests[0].Header.Get("Authorization"))
+	require.NoError(t, f.Close())
+}
+
+func TestHTTPFactoryError(t *testing.T) {
+	s := &influxServer{status: http.StatusBadRequest}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	f, err := NewHTTP(server.URL, "org", "bucket", WithFlushInterval(0))
+	require.NoError(t, err)
+	f.Counter(metrics.Options{Name: "counter"}).Inc(1)
+	err = f.Close()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+	assert.Contains(t, err.Error(), "bad point")
+}
+
+func TestHTTPFactoryInvalidURL(t *testing.T) {
+	_, err := NewHTTP(":not a url", "org", "bucket")
+	assert.Error(t, err)
+}
+
+func TestHTTPFactoryFlushInterval(t *testing.T) {
+	s := &influxServer{}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	f, err := NewHTTP(server.URL, "org", "bucket", WithFlushInterval(time.Millisecond))
+	require.NoError(t, err)
+	defer f.Close()
+	f.Gauge(metrics.Options{Name: "gauge"}).Update(1)
+
+	for i := 0; i < 1000 && len(s.lines()) == 0; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	require.NotEmpty(t, s.lines())
+	assert.True(t, strings.HasPrefix(s.lines()[0], "gauge value=1i "))
+}
+
+func TestFactoryEscaping(t *testing.T) {
+	s := &influxServer{}
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	f, err := NewHTTP(server.URL, "org", "bucket", WithFlushInterval(0))
+	require.NoError(t, err)
+	fixedClock(f)
+	f.Counter(metrics.Options{
+		Name: "my counter,x",
+		Tags: map[string]string{"k=1": "a b,c", "empty": ""},
+	}).Inc(1)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, []string{`my\ counter\,x,k\=1=a\ b\,c value=1i 1500000000000000000`}, s.lines())
+}
+
+func TestUDPFactory(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	f, err := NewUDP(conn.LocalAddr().String(), WithFlushInterval(0), WithMaxPacketSize(64))
+	require.NoError(t, err)
+	fixedClock(f)
+	f.Counter(metrics.Options{Name: "counter", Tags: map[string]string{"x": "y"}}).Inc(1)
+	f.Gauge(metrics.Options{Name: "gauge"}).Update(2)
+	require.NoError(t, f.Close())
+
+	buf := make([]byte, 65536)
+	var lines []string
+	for len(lines) < 2 {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		lines = append(lines, strings.Split(string(buf[:n]), "\n")...)
+	}
+	assert.Equal(t, []string{
+		"counter,x=y value=1i 1500000000000000000",
+		"gauge value=2i 1500000000000000000",
+	}, lines)
+}
+
+func TestUDPFactoryDialError(t *testing.T) {
+	_, err := NewUDP("not-an-address")
+	assert.Error(t, err)
+}
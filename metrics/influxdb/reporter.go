@@ -0,0 +1,129 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdb
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics/internal/aggregate"
+	"github.com/uber/jaeger-lib/metrics/internal/percentile"
+)
+
+var (
+	measurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `, "\n", `\ `)
+	tagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `, "\n", `\ `)
+)
+
+// reporter writes the aggregated metrics as line-protocol points, one per metric.
+type reporter struct {
+	writer writer
+	now    func() time.Time
+	tags   map[string]string
+	fields []string
+}
+
+// percentileFields returns the field key prefixes of the percentiles, e.g. ",p99=".
+func percentileFields(percentiles []float64) []string {
+	fields := make([]string, len(percentiles))
+	for i, p := range percentiles {
+		fields[i] = "," + percentile.Name(p) + "="
+	}
+	return fields
+}
+
+// Report writes a point per snapshot.
+func (r *reporter) Report(snapshots []aggregate.Snapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	timestamp := strconv.FormatInt(r.now().UnixNano(), 10)
+	points := make([][]byte, len(snapshots))
+	for i := range snapshots {
+		points[i] = r.point(&snapshots[i], timestamp)
+	}
+	return r.writer.write(points)
+}
+
+// Close releases the connection.
+func (r *reporter) Close() error {
+	return r.writer.close()
+}
+
+func (r *reporter) point(s *aggregate.Snapshot, timestamp string) []byte {
+	if s.Type == aggregate.CounterType || s.Type == aggregate.GaugeType {
+		return r.format(s, "value="+formatInt(s.Value), timestamp)
+	}
+	if s.Count == 0 {
+		return r.format(s, "count=0i", timestamp)
+	}
+	var fields strings.Builder
+	fields.WriteString("count=" + formatInt(s.Count))
+	fields.WriteString(",sum=" + formatFloat(s.Sum))
+	fields.WriteString(",min=" + formatFloat(s.Min))
+	fields.WriteString(",max=" + formatFloat(s.Max))
+	fields.WriteString(",mean=" + formatFloat(s.Mean()))
+	for i, v := range s.Percentiles {
+		fields.WriteString(r.fields[i] + formatFloat(v))
+	}
+	return r.format(s, fields.String(), timestamp)
+}
+
+func (r *reporter) format(s *aggregate.Snapshot, fields string, timestamp string) []byte {
+	series := r.series(s.Name, s.Tags)
+	b := make([]byte, 0, len(series)+len(fields)+len(timestamp)+2)
+	b = append(b, series...)
+	b = append(b, ' ')
+	b = append(b, fields...)
+	b = append(b, ' ')
+	return append(b, timestamp...)
+}
+
+// series returns the measurement and tags section of the point of a metric,
+// e.g. "requests,result=ok", with the tags sorted by key as recommended
+// for write performance.
+func (r *reporter) series(name string, tags map[string]string) string {
+	merged := make(map[string]string, len(r.tags)+len(tags))
+	for k, v := range r.tags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	keys := make([]string, 0, len(merged))
+	for k := range merged {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	s := measurementEscaper.Replace(name)
+	for _, k := range keys {
+		if merged[k] == "" {
+			// empty tag values are not allowed in the line protocol
+			continue
+		}
+		s += "," + tagEscaper.Replace(k) + "=" + tagEscaper.Replace(merged[k])
+	}
+	return s
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func formatInt(v int64) string {
+	return strconv.FormatInt(v, 10) + "i"
+}
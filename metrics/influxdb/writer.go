@@ -0,0 +1,116 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdb
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/uber/jaeger-lib/metrics/statsd"
+)
+
+// writer sends line-protocol points to InfluxDB.
+type writer interface {
+	write(points [][]byte) error
+	close() error
+}
+
+// httpWriter writes points with the InfluxDB 2.x /api/v2/write endpoint,
+// in batches of up to batchSize points per request.
+type httpWriter struct {
+	client    *http.Client
+	url       string
+	token     string
+	batchSize int
+}
+
+func newHTTPWriter(serverURL, org, bucket, token string, batchSize int, client *http.Client) (*httpWriter, error) {
+	u, err := url.Parse(strings.TrimSuffix(serverURL, "/") + "/api/v2/write")
+	if err != nil {
+		return nil, err
+	}
+	query := u.Query()
+	query.Set("org", org)
+	query.Set("bucket", bucket)
+	query.Set("precision", "ns")
+	u.RawQuery = query.Encode()
+	return &httpWriter{
+		client:    client,
+		url:       u.String(),
+		token:     token,
+		batchSize: batchSize,
+	}, nil
+}
+
+func (w *httpWriter) write(points [][]byte) error {
+	for len(points) > 0 {
+		n := len(points)
+		if n > w.batchSize {
+			n = w.batchSize
+		}
+		if err := w.post(bytes.Join(points[:n], []byte{'\n'})); err != nil {
+			return err
+		}
+		points = points[n:]
+	}
+	return nil
+}
+
+func (w *httpWriter) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	if w.token != "" {
+		req.Header.Set("Authorization", "Token "+w.token)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("influxdb: write failed with status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	_, err = io.Copy(ioutil.Discard, resp.Body)
+	return err
+}
+
+func (w *httpWriter) close() error {
+	return nil
+}
+
+// udpWriter writes points in datagrams of up to a maximum size.
+type udpWriter struct {
+	sender *statsd.Sender
+}
+
+func (w *udpWriter) write(points [][]byte) error {
+	for _, p := range points {
+		w.sender.Send(p)
+	}
+	return w.sender.Flush()
+}
+
+func (w *udpWriter) close() error {
+	return w.sender.Close()
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package aggregate implements the metrics of the backends that aggregate values
+// locally between flushes, leaving to each backend only the format in which the
+// aggregated values are written.
+package aggregate
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/adapters"
+)
+
+// Reporter writes the snapshots of the metrics of a Factory in the format of a backend.
+// If the Reporter is also an io.Closer, it is closed by Factory.Close after the last flush.
+type Reporter interface {
+	// Report is called on each flush with the snapshots of all the metrics,
+	// in the order the metrics were created.
+	Report(snapshots []Snapshot) error
+}
+
+// Options control how a Factory aggregates and flushes the metrics.
+type Options struct {
+	// FlushInterval is how often the metrics are reported. A non-positive interval
+	// disables the periodic flushes, Flush must be called instead.
+	FlushInterval time.Duration
+
+	// Percentiles, between 0 and 100, are reported for Timers and Histograms.
+	Percentiles []float64
+
+	// Adapters are the separators used to build the names of the metrics in namespaces.
+	Adapters adapters.Options
+
+	// ErrorHandler is called with the errors of the periodic flushes.
+	// If nil, such errors are dropped.
+	ErrorHandler func(error)
+}
+
+// Factory implements metrics.Factory by aggregating the values of the metrics locally
+// and passing a snapshot of them to a Reporter on each flush interval.
+//
+// Counters report the sum of the increments during the interval and Gauges the last value.
+// Timers and Histograms report the count, sum, min and max of the values recorded during
+// the interval, as well as percentiles. Timers are in milliseconds.
+type Factory struct {
+	metrics.Factory
+	reporter    Reporter
+	percentiles []float64
+	errHandler  func(error)
+	lock        sync.Mutex
+	aggregators []aggregator
+	stop        chan struct{}
+	wg          sync.WaitGroup
+	once        sync.Once
+}
+
+// New creates a Factory reporting to reporter, and starts the periodic flushes.
+// Close must be called to report the last aggregated values.
+func New(reporter Reporter, options Options) *Factory {
+	f := &Factory{
+		reporter:    reporter,
+		percentiles: options.Percentiles,
+		errHandler:  options.ErrorHandler,
+		stop:        make(chan struct{}),
+	}
+	if f.errHandler == nil {
+		f.errHandler = func(error) {}
+	}
+	f.Factory = adapters.WrapFactoryWithTags(&factory{parent: f}, options.Adapters)
+	if options.FlushInterval > 0 {
+		f.wg.Add(1)
+		go f.flushLoop(options.FlushInterval)
+	}
+	return f
+}
+
+func (f *Factory) flushLoop(flushInterval time.Duration) {
+	defer f.wg.Done()
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := f.Flush(); err != nil {
+				f.errHandler(err)
+			}
+		case <-f.stop:
+			return
+		}
+	}
+}
+
+func (f *Factory) register(a aggregator) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.aggregators = append(f.aggregators, a)
+}
+
+// Flush reports the values aggregated since the previous flush and resets them.
+func (f *Factory) Flush() error {
+	f.lock.Lock()
+	aggregators := f.aggregators
+	f.lock.Unlock()
+	snapshots := make([]Snapshot, len(aggregators))
+	for i, a := range aggregators {
+		a.snapshot(&snapshots[i])
+	}
+	return f.reporter.Report(snapshots)
+}
+
+// Close stops the periodic flushes, reports the last aggregated values and closes
+// the Reporter if it is an io.Closer.
+func (f *Factory) Close() error {
+	var err error
+	f.once.Do(func() {
+		close(f.stop)
+		f.wg.Wait()
+		err = f.Flush()
+		if closer, ok := f.reporter.(io.Closer); ok {
+			if cerr := closer.Close(); err == nil {
+				err = cerr
+			}
+		}
+	})
+	return err
+}
+
+// factory implements adapters.FactoryWithTags
+type factory struct {
+	parent *Factory
+}
+
+func (f *factory) Counter(options metrics.Options) metrics.Counter {
+	c := &counter{identity: newIdentity(CounterType, options.Name, options.Tags)}
+	f.parent.register(c)
+	return c
+}
+
+func (f *factory) Gauge(options metrics.Options) metrics.Gauge {
+	g := &gauge{identity: newIdentity(GaugeType, options.Name, options.Tags)}
+	f.parent.register(g)
+	return g
+}
+
+func (f *factory) Timer(options metrics.TimerOptions) metrics.Timer {
+	// percentiles are reported instead of buckets
+	t := timer{newDistribution(newIdentity(TimerType, options.Name, options.Tags), f.parent.percentiles)}
+	f.parent.register(t)
+	return t
+}
+
+func (f *factory) Histogram(options metrics.HistogramOptions) metrics.Histogram {
+	// percentiles are reported instead of buckets
+	h := histogram{newDistribution(newIdentity(HistogramType, options.Name, options.Tags), f.parent.percentiles)}
+	f.parent.register(h)
+	return h
+}
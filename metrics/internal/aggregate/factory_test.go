@@ -0,0 +1,142 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregate
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+var _ metrics.Factory = new(Factory)
+
+// reporter records the reported snapshots.
+type reporter struct {
+	sync.Mutex
+	flushes [][]Snapshot
+	err     error
+	closed  bool
+}
+
+func (r *reporter) Report(snapshots []Snapshot) error {
+	r.Lock()
+	defer r.Unlock()
+	r.flushes = append(r.flushes, snapshots)
+	return r.err
+}
+
+func (r *reporter) flushCount() int {
+	r.Lock()
+	defer r.Unlock()
+	return len(r.flushes)
+}
+
+type closingReporter struct {
+	reporter
+}
+
+func (r *closingReporter) Close() error {
+	r.closed = true
+	return errors.New("close error")
+}
+
+func TestFactory(t *testing.T) {
+	r := &reporter{}
+	f := New(r, Options{Percentiles: []float64{50, 99.9}})
+	ns := f.Namespace(metrics.NSOptions{Name: "query", Tags: map[string]string{"a": "b"}})
+	c := ns.Counter(metrics.Options{Name: "requests", Tags: map[string]string{"result": "ok"}})
+	c.Inc(3)
+	c.Inc(4)
+	ns.Gauge(metrics.Options{Name: "queue"}).Update(42)
+	timer := ns.Timer(metrics.TimerOptions{Name: "latency"})
+	timer.Record(2 * time.Millisecond)
+	timer.Record(4 * time.Millisecond)
+	ns.Histogram(metrics.HistogramOptions{Name: "size"})
+	require.NoError(t, f.Flush())
+
+	assert.Equal(t, []Snapshot{
+		{Type: CounterType, Name: "query.requests", Tags: map[string]string{"a": "b", "result": "ok"}, Value: 7},
+		{Type: GaugeType, Name: "query.queue", Tags: map[string]string{"a": "b"}, Value: 42},
+		{Type: TimerType, Name: "query.latency", Tags: map[string]string{"a": "b"},
+			Count: 2, Sum: 6, Min: 2, Max: 4, Percentiles: []float64{2, 4}},
+		{Type: HistogramType, Name: "query.size", Tags: map[string]string{"a": "b"}},
+	}, r.flushes[0])
+	assert.EqualValues(t, 3, r.flushes[0][2].Mean())
+
+	// the values are reset after each flush, except for gauges
+	require.NoError(t, f.Close())
+	require.Len(t, r.flushes, 2)
+	assert.EqualValues(t, 0, r.flushes[1][0].Value)
+	assert.EqualValues(t, 42, r.flushes[1][1].Value)
+	assert.EqualValues(t, 0, r.flushes[1][2].Count)
+	assert.Nil(t, r.flushes[1][2].Percentiles)
+	assert.EqualValues(t, 0, r.flushes[1][2].Mean())
+
+	require.NoError(t, f.Close())
+	assert.Len(t, r.flushes, 2, "only the first Close flushes")
+}
+
+func TestFactoryReservoir(t *testing.T) {
+	r := &reporter{}
+	f := New(r, Options{Percentiles: []float64{0, 100}})
+	h := f.Histogram(metrics.HistogramOptions{Name: "h"})
+	for i := 1; i <= 10*reservoirSize; i++ {
+		h.Record(float64(i))
+	}
+	require.NoError(t, f.Flush())
+	s := r.flushes[0][0]
+	assert.EqualValues(t, 10*reservoirSize, s.Count)
+	assert.EqualValues(t, 1, s.Min)
+	assert.EqualValues(t, 10*reservoirSize, s.Max)
+	assert.True(t, s.Percentiles[0] >= s.Min && s.Percentiles[1] <= s.Max)
+}
+
+func TestFactoryFlushInterval(t *testing.T) {
+	r := &reporter{err: errors.New("flush error")}
+	errs := make(chan error, 100)
+	f := New(r, Options{
+		FlushInterval: time.Millisecond,
+		ErrorHandler:  func(err error) { errs <- err },
+	})
+	select {
+	case err := <-errs:
+		assert.EqualError(t, err, "flush error")
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a periodic flush")
+	}
+	assert.EqualError(t, f.Close(), "flush error")
+	assert.True(t, r.flushCount() >= 2)
+}
+
+func TestFactoryClosesReporter(t *testing.T) {
+	r := &closingReporter{}
+	f := New(r, Options{})
+	assert.EqualError(t, f.Close(), "close error")
+	assert.True(t, r.closed)
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.EqualValues(t, 1, percentile(sorted, 0))
+	assert.EqualValues(t, 5, percentile(sorted, 50))
+	assert.EqualValues(t, 10, percentile(sorted, 99))
+	assert.EqualValues(t, 10, percentile(sorted, 100))
+}
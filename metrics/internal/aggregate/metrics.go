@@ -0,0 +1,206 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregate
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// reservoirSize bounds the number of values kept per flush interval to compute percentiles.
+const reservoirSize = 1028
+
+// Type is the type of an aggregated metric.
+type Type int
+
+// Types of metrics, as found in Snapshot.Type.
+const (
+	CounterType Type = iota
+	GaugeType
+	TimerType
+	HistogramType
+)
+
+// Snapshot holds the values of a metric accumulated since the previous flush.
+// Counters and Gauges set Value, the sum of the increments and the last value
+// respectively. Timers and Histograms set Count, and unless it is zero Sum, Min,
+// Max and Percentiles, in the order of Options.Percentiles.
+type Snapshot struct {
+	Type        Type
+	Name        string
+	Tags        map[string]string
+	Value       int64
+	Count       int64
+	Sum         float64
+	Min         float64
+	Max         float64
+	Percentiles []float64
+}
+
+// Mean returns the mean of the values recorded by a Timer or Histogram.
+func (s *Snapshot) Mean() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Sum / float64(s.Count)
+}
+
+// aggregator accumulates updates of a metric locally and reports them on flush.
+type aggregator interface {
+	// snapshot fills s with the values accumulated since the previous flush
+	// and resets them.
+	snapshot(s *Snapshot)
+}
+
+// identity is the type, name and tags shared by all the snapshots of a metric.
+type identity struct {
+	typ  Type
+	name string
+	tags map[string]string
+}
+
+func newIdentity(typ Type, name string, tags map[string]string) identity {
+	copied := make(map[string]string, len(tags))
+	for k, v := range tags {
+		copied[k] = v
+	}
+	return identity{typ: typ, name: name, tags: copied}
+}
+
+func (id identity) fill(s *Snapshot) {
+	s.Type = id.typ
+	s.Name = id.name
+	s.Tags = id.tags
+}
+
+// counter reports the sum of the increments during the flush interval.
+type counter struct {
+	delta int64 // first for 64-bit alignment of atomic operations
+	identity
+}
+
+func (c *counter) Inc(delta int64) {
+	atomic.AddInt64(&c.delta, delta)
+}
+
+func (c *counter) snapshot(s *Snapshot) {
+	c.fill(s)
+	s.Value = atomic.SwapInt64(&c.delta, 0)
+}
+
+// gauge reports the last value.
+type gauge struct {
+	value int64 // first for 64-bit alignment of atomic operations
+	identity
+}
+
+func (g *gauge) Update(value int64) {
+	atomic.StoreInt64(&g.value, value)
+}
+
+func (g *gauge) snapshot(s *Snapshot) {
+	g.fill(s)
+	s.Value = atomic.LoadInt64(&g.value)
+}
+
+// distribution reports the count, sum, min and max of the values recorded during
+// the flush interval, and percentiles estimated from a uniform sample of them.
+type distribution struct {
+	identity
+	lock        sync.Mutex
+	percentiles []float64
+	count       int64
+	sum         float64
+	min         float64
+	max         float64
+	sample      []float64
+}
+
+func newDistribution(id identity, percentiles []float64) *distribution {
+	return &distribution{
+		identity:    id,
+		percentiles: percentiles,
+		sample:      make([]float64, 0, reservoirSize),
+	}
+}
+
+func (d *distribution) record(v float64) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.count++
+	d.sum += v
+	if d.count == 1 || v < d.min {
+		d.min = v
+	}
+	if d.count == 1 || v > d.max {
+		d.max = v
+	}
+	if len(d.sample) < reservoirSize {
+		d.sample = append(d.sample, v)
+	} else if i := rand.Int63n(d.count); i < reservoirSize {
+		d.sample[i] = v
+	}
+}
+
+func (d *distribution) snapshot(s *Snapshot) {
+	d.lock.Lock()
+	count, sum, min, max := d.count, d.sum, d.min, d.max
+	sample := append([]float64(nil), d.sample...)
+	d.count, d.sum, d.min, d.max = 0, 0, 0, 0
+	d.sample = d.sample[:0]
+	d.lock.Unlock()
+
+	d.fill(s)
+	s.Count = count
+	if count == 0 {
+		return
+	}
+	s.Sum, s.Min, s.Max = sum, min, max
+	sort.Float64s(sample)
+	s.Percentiles = make([]float64, len(d.percentiles))
+	for i, p := range d.percentiles {
+		s.Percentiles[i] = percentile(sample, p)
+	}
+}
+
+// percentile returns the nearest-rank percentile p, between 0 and 100, of sorted values.
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+type timer struct {
+	*distribution
+}
+
+// Record reports durations in milliseconds, the usual unit of backends aggregating timers.
+func (t timer) Record(d time.Duration) {
+	t.record(float64(d) / float64(time.Millisecond))
+}
+
+type histogram struct {
+	*distribution
+}
+
+func (h histogram) Record(v float64) {
+	h.record(v)
+}
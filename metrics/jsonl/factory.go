@@ -0,0 +1,118 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonl
+
+import (
+	"io"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics/adapters"
+	"github.com/uber/jaeger-lib/metrics/internal/aggregate"
+)
+
+// DefaultFlushInterval is how often the snapshots are written.
+const DefaultFlushInterval = 10 * time.Second
+
+// DefaultPercentiles are the percentiles reported for Timers and Histograms.
+var DefaultPercentiles = []float64{50, 95, 99}
+
+// Factory implements metrics.Factory by keeping the values of the metrics in memory
+// and writing a snapshot of them on each flush interval, as one JSON object per line
+// and per metric, e.g.
+//
+//	{"timestamp":"2026-01-02T15:04:05Z","type":"counter","name":"ns.requests","tags":{"result":"ok"},"value":3}
+//
+// Counters report the sum of the increments during the interval and Gauges the last value.
+// Timers and Histograms report the count, sum, min, max and mean of the values recorded
+// during the interval, as well as quantiles. Timers are in milliseconds. See Line.
+//
+// Each line is written with its own call to Write, so the writer can be os.Stdout,
+// a rotating file, or an adapter turning each call into a log record. Flush returns
+// the first write error, after which the rest of the snapshot is dropped.
+// Close does not close the writer.
+type Factory struct {
+	*aggregate.Factory
+	reporter *reporter
+}
+
+type options struct {
+	flushInterval time.Duration
+	scopeSep      string
+	percentiles   []float64
+	errorHandler  func(error)
+}
+
+// Option is a function that sets some option for the Factory constructor.
+type Option func(*options)
+
+// WithFlushInterval returns an option that sets how often the snapshots are written.
+// If not used, we fallback to DefaultFlushInterval.
+// A non-positive interval disables the periodic flushes, Flush must be called instead.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.flushInterval = interval
+	}
+}
+
+// WithScopeSeparator returns an option that sets the separator between namespace
+// names and metric names. If not used, we fallback to ".".
+func WithScopeSeparator(separator string) Option {
+	return func(opts *options) {
+		opts.scopeSep = separator
+	}
+}
+
+// WithPercentiles returns an option that sets the percentiles, between 0 and 100,
+// reported as quantiles of Timers and Histograms. If not used, we fallback to DefaultPercentiles.
+func WithPercentiles(percentiles []float64) Option {
+	return func(opts *options) {
+		opts.percentiles = percentiles
+	}
+}
+
+// WithErrorHandler returns an option that sets a function called with errors
+// of the periodic flushes. If not used, such errors are dropped.
+func WithErrorHandler(handler func(error)) Option {
+	return func(opts *options) {
+		opts.errorHandler = handler
+	}
+}
+
+// New creates a Factory writing snapshots of the metrics to writer.
+// Close must be called to write the last snapshot.
+func New(writer io.Writer, opts ...Option) *Factory {
+	options := &options{
+		flushInterval: DefaultFlushInterval,
+		percentiles:   DefaultPercentiles,
+		errorHandler:  func(error) {},
+	}
+	for _, o := range opts {
+		o(options)
+	}
+	r := &reporter{
+		writer: writer,
+		now:    time.Now,
+		keys:   quantileKeys(options.percentiles),
+	}
+	return &Factory{
+		Factory: aggregate.New(r, aggregate.Options{
+			FlushInterval: options.flushInterval,
+			Percentiles:   options.percentiles,
+			Adapters:      adapters.Options{ScopeSep: options.scopeSep},
+			ErrorHandler:  options.errorHandler,
+		}),
+		reporter: r,
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+var _ metrics.Factory = new(Factory)
+
+// recorder collects the buffers passed to each Write call.
+type recorder struct {
+	sync.Mutex
+	writes []string
+	err    error
+}
+
+func (r *recorder) Write(p []byte) (int, error) {
+	r.Lock()
+	defer r.Unlock()
+	if r.err != nil {
+		return 0, r.err
+	}
+	r.writes = append(r.writes, string(p))
+	return len(p), nil
+}
+
+func (r *recorder) reset() []string {
+	r.Lock()
+	defer r.Unlock()
+	writes := r.writes
+	r.writes = nil
+	return writes
+}
+
+func TestFactory(t *testing.T) {
+	w := &recorder{}
+	f := New(w, WithFlushInterval(0))
+	f.reporter.now = func() time.Time { return time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC) }
+	ns := f.Namespace(metrics.NSOptions{Name: "query", Tags: map[string]string{"a": "b"}})
+
+	c := ns.Counter(metrics.Options{Name: "requests", Tags: map[string]string{"result": "ok"}})
+	c.Inc(3)
+	c.Inc(4)
+	ns.Gauge(metrics.Options{Name: "queue"}).Update(42)
+	tm := ns.Timer(metrics.TimerOptions{Name: "latency"})
+	for i := 1; i <= 100; i++ {
+		tm.Record(time.Duration(i) * time.Millisecond)
+	}
+	ns.Histogram(metrics.HistogramOptions{Name: "size"})
+
+	require.NoError(t, f.Flush())
+	assert.Equal(t, []string{
+		`{"timestamp":"2026-01-02T15:04:05Z","type":"counter","name":"query.requests","tags":{"a":"b","result":"ok"},"value":7}` + "\n",
+		`{"timestamp":"2026-01-02T15:04:05Z","type":"gauge","name":"query.queue","tags":{"a":"b"},"value":42}` + "\n",
+		`{"timestamp":"2026-01-02T15:04:05Z","type":"timer","name":"query.latency","tags":{"a":"b"},"count":100,"sum":5050,"min":1,"max":100,"mean":50.5,"quantiles":{"p50":50,"p95":95,"p99":99}}` + "\n",
+		`{"timestamp":"2026-01-02T15:04:05Z","type":"histogram","name":"query.size","tags":{"a":"b"},"count":0}` + "\n",
+	}, w.reset())
+
+	// counters and distributions are reset, gauges keep their value
+	require.NoError(t, f.Flush())
+	writes := w.reset()
+	require.Len(t, writes, 4)
+	var line Line
+	require.NoError(t, json.Unmarshal([]byte(writes[0]), &line))
+	assert.EqualValues(t, 0, *line.Value)
+	require.NoError(t, json.Unmarshal([]byte(writes[1]), &line))
+	assert.EqualValues(t, 42, *line.Value)
+	line = Line{}
+	require.NoError(t, json.Unmarshal([]byte(writes[2]), &line))
+	assert.EqualValues(t, 0, *line.Count)
+	assert.Nil(t, line.Sum)
+}
+
+func TestFactoryOptions(t *testing.T) {
+	var buf bytes.Buffer
+	f := New(&buf, WithFlushInterval(0), WithScopeSeparator("_"), WithPercentiles([]float64{99.9}))
+	f.Namespace(metrics.NSOptions{Name: "ns"}).Histogram(metrics.HistogramOptions{Name: "h"}).Record(1.5)
+	require.NoError(t, f.Close())
+
+	var line Line
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, HistogramType, line.Type)
+	assert.Equal(t, "ns_h", line.Name)
+	assert.Equal(t, map[string]string{}, line.Tags)
+	assert.Equal(t, map[string]float64{"p99_9": 1.5}, line.Quantiles)
+}
+
+func TestFactoryFlushLoop(t *testing.T) {
+	w := &recorder{err: errors.New("disk full")}
+	errs := make(chan error, 10)
+	f := New(w, WithFlushInterval(time.Millisecond), WithErrorHandler(func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}))
+	f.Counter(metrics.Options{Name: "counter"}).Inc(1)
+	assert.EqualError(t, <-errs, "disk full")
+
+	w.Lock()
+	w.err = nil
+	w.Unlock()
+	for i := 0; i < 1000 && len(w.reset()) == 0; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	require.NoError(t, f.Close())
+	require.NoError(t, f.Close())
+	for _, write := range w.reset() {
+		assert.True(t, strings.HasSuffix(write, "}\n"))
+	}
+}
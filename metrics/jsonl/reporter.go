@@ -0,0 +1,119 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonl
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics/internal/aggregate"
+	"github.com/uber/jaeger-lib/metrics/internal/percentile"
+)
+
+// Types of metrics, as found in Line.Type.
+const (
+	CounterType   = "counter"
+	GaugeType     = "gauge"
+	TimerType     = "timer"
+	HistogramType = "histogram"
+)
+
+var types = map[aggregate.Type]string{
+	aggregate.CounterType:   CounterType,
+	aggregate.GaugeType:     GaugeType,
+	aggregate.TimerType:     TimerType,
+	aggregate.HistogramType: HistogramType,
+}
+
+// Line is one JSON line of a snapshot, describing the values of a metric accumulated
+// since the previous snapshot. Counters and Gauges set Value, the sum of the increments
+// and the last value respectively. Timers and Histograms set Count, and unless it is
+// zero Sum, Min, Max, Mean and Quantiles, keyed by percentile, e.g. "p99" or "p99_9".
+type Line struct {
+	Timestamp time.Time          `json:"timestamp"`
+	Type      string             `json:"type"`
+	Name      string             `json:"name"`
+	Tags      map[string]string  `json:"tags"`
+	Value     *int64             `json:"value,omitempty"`
+	Count     *int64             `json:"count,omitempty"`
+	Sum       *float64           `json:"sum,omitempty"`
+	Min       *float64           `json:"min,omitempty"`
+	Max       *float64           `json:"max,omitempty"`
+	Mean      *float64           `json:"mean,omitempty"`
+	Quantiles map[string]float64 `json:"quantiles,omitempty"`
+}
+
+// reporter writes the aggregated metrics as JSON lines, one per metric.
+type reporter struct {
+	lock   sync.Mutex
+	writer io.Writer
+	now    func() time.Time
+	keys   []string
+}
+
+// quantileKeys returns the keys of the percentiles in Line.Quantiles, e.g. "p99".
+func quantileKeys(percentiles []float64) []string {
+	keys := make([]string, len(percentiles))
+	for i, p := range percentiles {
+		keys[i] = percentile.Name(p)
+	}
+	return keys
+}
+
+// Report writes a line per snapshot, in the order the metrics were created.
+func (r *reporter) Report(snapshots []aggregate.Snapshot) error {
+	timestamp := r.now().UTC()
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i := range snapshots {
+		b, err := json.Marshal(r.line(&snapshots[i], timestamp))
+		if err != nil {
+			return err
+		}
+		if _, err := r.writer.Write(append(b, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reporter) line(s *aggregate.Snapshot, timestamp time.Time) *Line {
+	line := &Line{
+		Timestamp: timestamp,
+		Type:      types[s.Type],
+		Name:      s.Name,
+		Tags:      s.Tags,
+	}
+	if s.Type == aggregate.CounterType || s.Type == aggregate.GaugeType {
+		line.Value = &s.Value
+		return line
+	}
+	line.Count = &s.Count
+	if s.Count == 0 {
+		return line
+	}
+	mean := s.Mean()
+	line.Sum, line.Min, line.Max, line.Mean = &s.Sum, &s.Min, &s.Max, &mean
+	if len(s.Percentiles) == 0 {
+		return line
+	}
+	line.Quantiles = make(map[string]float64, len(s.Percentiles))
+	for i, v := range s.Percentiles {
+		line.Quantiles[r.keys[i]] = v
+	}
+	return line
+}
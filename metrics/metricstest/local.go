@@ -28,7 +28,9 @@ import (
 // rather than being global (to facilitate testing).
 
 // A Backend is a metrics provider which aggregates data in-vm, and
-// allows exporting snapshots to shove the data into a remote collector
+// allows exporting snapshots to shove the data into a remote collector.
+// It keeps every value recorded by its timers and histograms until Clear
+// for the assertions, so it is meant for tests, not long-running processes.
 type Backend struct {
 	cm         sync.Mutex
 	gm         sync.Mutex
@@ -140,6 +142,7 @@ func (b *Backend) RecordHistogram(name string, tags map[string]string, v float64
 	histogram := b.findOrCreateHistogram(name)
 	histogram.Lock()
 	histogram.hist.Current.RecordValue(int64(v))
+	histogram.values = append(histogram.values, v)
 	histogram.Unlock()
 }
 
@@ -160,6 +163,8 @@ func (b *Backend) findOrCreateHistogram(name string) *localBackendHistogram {
 type localBackendHistogram struct {
 	sync.Mutex
 	hist *hdrhistogram.WindowedHistogram
+	// all the values, for the assertions, unbounded until Clear
+	values []float64
 }
 
 // RecordTimer records a timing duration
@@ -168,6 +173,7 @@ func (b *Backend) RecordTimer(name string, tags map[string]string, d time.Durati
 	timer := b.findOrCreateTimer(name)
 	timer.Lock()
 	timer.hist.Current.RecordValue(int64(d / time.Millisecond))
+	timer.values = append(timer.values, float64(d))
 	timer.Unlock()
 }
 
@@ -188,6 +194,8 @@ func (b *Backend) findOrCreateTimer(name string) *localBackendTimer {
 type localBackendTimer struct {
 	sync.Mutex
 	hist *hdrhistogram.WindowedHistogram
+	// all the durations in nanoseconds, for the assertions, unbounded until Clear
+	values []float64
 }
 
 var (
@@ -254,6 +262,32 @@ func (b *Backend) Snapshot() (counters, gauges map[string]int64) {
 	return
 }
 
+// timerValues returns the durations recorded by the timers of each key, in nanoseconds.
+func (b *Backend) timerValues() map[string][]float64 {
+	b.tm.Lock()
+	defer b.tm.Unlock()
+	values := make(map[string][]float64, len(b.timers))
+	for key, timer := range b.timers {
+		timer.Lock()
+		values[key] = append([]float64(nil), timer.values...)
+		timer.Unlock()
+	}
+	return values
+}
+
+// histogramValues returns the values recorded by the histograms of each key.
+func (b *Backend) histogramValues() map[string][]float64 {
+	b.hm.Lock()
+	defer b.hm.Unlock()
+	values := make(map[string][]float64, len(b.histograms))
+	for key, histogram := range b.histograms {
+		histogram.Lock()
+		values[key] = append([]float64(nil), histogram.values...)
+		histogram.Unlock()
+	}
+	return values
+}
+
 // Stop cleanly closes the background goroutine spawned by NewBackend.
 func (b *Backend) Stop() {
 	close(b.stop)
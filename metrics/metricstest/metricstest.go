@@ -15,7 +15,13 @@
 package metricstest
 
 import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -29,7 +35,41 @@ type ExpectedMetric struct {
 	Value int
 }
 
-// TODO do something similar for Timers
+// ExpectedTimer contains the statistics of a timer under test, over all the durations
+// it recorded since the creation of the Backend or its last Clear.
+type ExpectedTimer struct {
+	Name string
+	Tags map[string]string
+	// Count is the number of recorded durations.
+	Count int
+	// Sum, Min and Max are only checked if not nil.
+	Sum *time.Duration
+	Min *time.Duration
+	Max *time.Duration
+	// Percentiles are the expected durations by percentile, between 0 and 100,
+	// computed with the nearest-rank method.
+	Percentiles map[float64]time.Duration
+	// Tolerance is the largest difference allowed for Sum, Min, Max and Percentiles.
+	Tolerance time.Duration
+}
+
+// ExpectedHistogram contains the statistics of a histogram under test, over all the values
+// it recorded since the creation of the Backend or its last Clear.
+type ExpectedHistogram struct {
+	Name string
+	Tags map[string]string
+	// Count is the number of recorded values.
+	Count int
+	// Sum, Min and Max are only checked if not nil.
+	Sum *float64
+	Min *float64
+	Max *float64
+	// Percentiles are the expected values by percentile, between 0 and 100,
+	// computed with the nearest-rank method.
+	Percentiles map[float64]float64
+	// Tolerance is the largest difference allowed for Sum, Min, Max and Percentiles.
+	Tolerance float64
+}
 
 // AssertCounterMetrics checks if counter metrics exist.
 func (f *Factory) AssertCounterMetrics(t *testing.T, expectedMetrics ...ExpectedMetric) {
@@ -43,6 +83,167 @@ func (f *Factory) AssertGaugeMetrics(t *testing.T, expectedMetrics ...ExpectedMe
 	assertMetrics(t, gauges, expectedMetrics...)
 }
 
+// AssertTimerMetrics checks the statistics of timer metrics. If a timer is missing,
+// the failure lists the names of the nearest existing timers.
+func (f *Factory) AssertTimerMetrics(t *testing.T, expectedMetrics ...ExpectedTimer) {
+	actualMetrics := f.timerValues()
+	for _, expected := range expectedMetrics {
+		percentiles := make(map[float64]float64, len(expected.Percentiles))
+		for p, d := range expected.Percentiles {
+			percentiles[p] = float64(d)
+		}
+		assertDistribution(t, "timer", actualMetrics, expectedDistribution{
+			name:        expected.Name,
+			tags:        expected.Tags,
+			count:       expected.Count,
+			sum:         durationValue(expected.Sum),
+			min:         durationValue(expected.Min),
+			max:         durationValue(expected.Max),
+			percentiles: percentiles,
+			tolerance:   float64(expected.Tolerance),
+			format: func(v float64) string {
+				return time.Duration(v).String()
+			},
+		})
+	}
+}
+
+// durationValue returns the nanoseconds of d, or nil if d is nil.
+func durationValue(d *time.Duration) *float64 {
+	if d == nil {
+		return nil
+	}
+	v := float64(*d)
+	return &v
+}
+
+// AssertHistogramMetrics checks the statistics of histogram metrics. If a histogram is
+// missing, the failure lists the names of the nearest existing histograms.
+func (f *Factory) AssertHistogramMetrics(t *testing.T, expectedMetrics ...ExpectedHistogram) {
+	actualMetrics := f.histogramValues()
+	for _, expected := range expectedMetrics {
+		assertDistribution(t, "histogram", actualMetrics, expectedDistribution{
+			name:        expected.Name,
+			tags:        expected.Tags,
+			count:       expected.Count,
+			sum:         expected.Sum,
+			min:         expected.Min,
+			max:         expected.Max,
+			percentiles: expected.Percentiles,
+			tolerance:   expected.Tolerance,
+			format: func(v float64) string {
+				return strconv.FormatFloat(v, 'g', -1, 64)
+			},
+		})
+	}
+}
+
+// expectedDistribution is the common form of ExpectedTimer and ExpectedHistogram.
+type expectedDistribution struct {
+	name        string
+	tags        map[string]string
+	count       int
+	sum         *float64
+	min         *float64
+	max         *float64
+	percentiles map[float64]float64
+	tolerance   float64
+	format      func(float64) string
+}
+
+func assertDistribution(t assert.TestingT, kind string, actualMetrics map[string][]float64, expected expectedDistribution) {
+	key := metrics.GetKey(expected.name, expected.tags, "|", "=")
+	values, ok := actualMetrics[key]
+	if !ok {
+		assert.Fail(t, fmt.Sprintf("%s %q not found", kind, key), "nearest %ss: %s", kind, nearestKeys(key, actualMetrics))
+		return
+	}
+	msg := fmt.Sprintf("expected %s name: %s, tags: %+v", kind, expected.name, expected.tags)
+	assert.Equal(t, expected.count, len(values), "count of the "+msg)
+	if len(values) == 0 {
+		return
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	check := func(stat string, expectedValue, actualValue float64) {
+		if math.Abs(expectedValue-actualValue) > expected.tolerance {
+			assert.Fail(t, fmt.Sprintf("%s of the %s: %s != %s, with a tolerance of %s", stat, msg,
+				expected.format(expectedValue), expected.format(actualValue), expected.format(expected.tolerance)))
+		}
+	}
+	if expected.sum != nil {
+		check("sum", *expected.sum, sum)
+	}
+	if expected.min != nil {
+		check("min", *expected.min, sorted[0])
+	}
+	if expected.max != nil {
+		check("max", *expected.max, sorted[len(sorted)-1])
+	}
+	percentiles := make([]float64, 0, len(expected.percentiles))
+	for p := range expected.percentiles {
+		percentiles = append(percentiles, p)
+	}
+	sort.Float64s(percentiles)
+	for _, p := range percentiles {
+		rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+		if rank < 1 {
+			rank = 1
+		} else if rank > len(sorted) {
+			rank = len(sorted)
+		}
+		check("p"+strconv.FormatFloat(p, 'f', -1, 64), expected.percentiles[p], sorted[rank-1])
+	}
+}
+
+// nearestKeys returns up to 3 keys of actualMetrics, the closest to key first.
+func nearestKeys(key string, actualMetrics map[string][]float64) string {
+	if len(actualMetrics) == 0 {
+		return "none recorded"
+	}
+	keys := make([]string, 0, len(actualMetrics))
+	distances := make(map[string]int, len(actualMetrics))
+	for k := range actualMetrics {
+		keys = append(keys, k)
+		distances[k] = editDistance(key, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if distances[keys[i]] != distances[keys[j]] {
+			return distances[keys[i]] < distances[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > 3 {
+		keys = keys[:3]
+	}
+	return strings.Join(keys, ", ")
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
 func assertMetrics(t *testing.T, actualMetrics map[string]int64, expectedMetrics ...ExpectedMetric) {
 	for _, expected := range expectedMetrics {
 		key := metrics.GetKey(expected.Name, expected.Tags, "|", "=")
@@ -1,7 +1,14 @@
 package metricstest
 
 import (
+	"fmt"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
 )
 
 func TestAssertMetrics(t *testing.T) {
@@ -13,3 +20,125 @@ func TestAssertMetrics(t *testing.T) {
 	f.AssertCounterMetrics(t, ExpectedMetric{Name: "counter", Tags: tags, Value: 1})
 	f.AssertGaugeMetrics(t, ExpectedMetric{Name: "gauge", Tags: tags, Value: 11})
 }
+
+func TestAssertTimerMetrics(t *testing.T) {
+	f := NewFactory(0)
+	defer f.Stop()
+	tags := map[string]string{"key": "value"}
+	timer := f.Namespace(metrics.NSOptions{Name: "ns"}).Timer(metrics.TimerOptions{Name: "latency", Tags: tags})
+	for i := 100; i > 0; i-- {
+		timer.Record(time.Duration(i) * time.Millisecond)
+	}
+	timer.Record(1500 * time.Microsecond)
+
+	wantSum, wantMin, wantMax := 5051500*time.Microsecond, time.Millisecond, 100*time.Millisecond
+	f.AssertTimerMetrics(t, ExpectedTimer{
+		Name:  "ns.latency",
+		Tags:  tags,
+		Count: 101,
+		Sum:   &wantSum,
+		Min:   &wantMin,
+		Max:   &wantMax,
+		Percentiles: map[float64]time.Duration{
+			0:  time.Millisecond,
+			50: 50 * time.Millisecond,
+			99: 99 * time.Millisecond,
+		},
+	})
+	f.AssertTimerMetrics(t, ExpectedTimer{
+		Name:        "ns.latency",
+		Tags:        tags,
+		Count:       101,
+		Percentiles: map[float64]time.Duration{90: 90 * time.Millisecond},
+		Tolerance:   time.Millisecond,
+	})
+}
+
+func TestAssertHistogramMetrics(t *testing.T) {
+	f := NewFactory(0)
+	defer f.Stop()
+	histogram := f.Histogram(metrics.HistogramOptions{Name: "size"})
+	histogram.Record(0.5)
+	histogram.Record(2.25)
+	histogram.Record(1)
+
+	wantSum, wantMin, wantMax := 3.75, 0.5, 2.25
+	f.AssertHistogramMetrics(t, ExpectedHistogram{
+		Name:        "size",
+		Count:       3,
+		Sum:         &wantSum,
+		Min:         &wantMin,
+		Max:         &wantMax,
+		Percentiles: map[float64]float64{50: 1, 100: 2.25},
+	})
+
+	f.Clear()
+	f.Histogram(metrics.HistogramOptions{Name: "size"}).Record(4)
+	wantMax = 4
+	f.AssertHistogramMetrics(t, ExpectedHistogram{Name: "size", Count: 1, Max: &wantMax})
+
+	f.Clear()
+	f.Histogram(metrics.HistogramOptions{Name: "size"}).Record(0)
+	zero := 0.0
+	f.AssertHistogramMetrics(t, ExpectedHistogram{Name: "size", Count: 1, Sum: &zero, Min: &zero, Max: &zero})
+}
+
+// failures records the failures of assertions.
+type failures []string
+
+func (f *failures) Errorf(format string, args ...interface{}) {
+	*f = append(*f, fmt.Sprintf(format, args...))
+}
+
+func TestAssertDistributionFailures(t *testing.T) {
+	actualMetrics := map[string][]float64{
+		"latency|method=GET":  {1, 2, 3},
+		"latency|method=POST": {4},
+		"requests":            {1},
+		"other":               {1},
+	}
+	wantSum, wantMax := 7.0, 3.0
+	expected := func(name string) expectedDistribution {
+		return expectedDistribution{
+			name:        name,
+			tags:        map[string]string{"method": "GET"},
+			count:       3,
+			sum:         &wantSum,
+			max:         &wantMax,
+			percentiles: map[float64]float64{50: 2},
+			tolerance:   0.5,
+			format:      func(v float64) string { return fmt.Sprint(v) },
+		}
+	}
+
+	var f failures
+	assertDistribution(&f, "timer", actualMetrics, expected("latency"))
+	require.Len(t, f, 1)
+	assert.Contains(t, f[0], "sum of the expected timer name: latency, tags: map[method:GET]: 7 != 6, with a tolerance of 0.5")
+
+	f = nil
+	zero := 0.0
+	withMin := expected("latency")
+	withMin.sum, withMin.min = nil, &zero
+	assertDistribution(&f, "timer", actualMetrics, withMin)
+	require.Len(t, f, 1)
+	assert.Contains(t, f[0], "min of the expected timer name: latency, tags: map[method:GET]: 0 != 1, with a tolerance of 0.5")
+
+	f = nil
+	assertDistribution(&f, "timer", actualMetrics, expected("latencies"))
+	require.Len(t, f, 1)
+	assert.Contains(t, f[0], `timer "latencies|method=GET" not found`)
+	assert.Contains(t, f[0], "nearest timers: latency|method=GET, latency|method=POST, ")
+
+	f = nil
+	assertDistribution(&f, "histogram", map[string][]float64{}, expected("latency"))
+	require.Len(t, f, 1)
+	assert.Contains(t, f[0], "nearest histograms: none recorded")
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("abc", "abc"))
+	assert.Equal(t, 3, editDistance("", "abc"))
+	assert.Equal(t, 3, editDistance("latency", "latencies"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus
+
+import (
+	"math"
+	"sync"
+
+	"go.opencensus.io/stats/view"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+// ViewExporter implements view.Exporter by forwarding the view data into a metrics.Factory,
+// e.g. to expose views published by dependencies through the Prometheus factory.
+// It is registered with view.RegisterExporter, or with the Meter the views belong to.
+//
+// Count and Sum aggregations are forwarded to Counters, incremented by the change of the
+// cumulative value since the previous export. LastValue aggregations are forwarded to Gauges.
+// Distribution aggregations are forwarded to Histograms with the same buckets, by recording
+// a single value in each bucket that received new values since the previous export: the
+// bucket's upper bound, or the maximum for the last bucket. Replaying every new value would
+// cost a Record per observation, so the Histograms only count the buckets that changed at
+// each export, not the observations, and approximate the sum.
+// Values are truncated to integers for Counters and Gauges.
+type ViewExporter struct {
+	factory  metrics.Factory
+	lock     sync.Mutex
+	previous map[string]*previousRow
+}
+
+// previousRow is the cumulative data of a row at the previous export.
+type previousRow struct {
+	value          float64
+	countPerBucket []int64
+}
+
+// NewViewExporter creates a ViewExporter forwarding the view data into the factory.
+func NewViewExporter(factory metrics.Factory) *ViewExporter {
+	return &ViewExporter{
+		factory:  factory,
+		previous: make(map[string]*previousRow),
+	}
+}
+
+// ExportView implements view.Exporter.
+func (e *ViewExporter) ExportView(data *view.Data) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for _, row := range data.Rows {
+		tags := make(map[string]string, len(row.Tags))
+		for _, t := range row.Tags {
+			tags[t.Key.Name()] = t.Value
+		}
+		key := metrics.GetKey(data.View.Name, tags, "|", "=")
+		prev, ok := e.previous[key]
+		if !ok {
+			prev = &previousRow{}
+			e.previous[key] = prev
+		}
+		switch d := row.Data.(type) {
+		case *view.CountData:
+			e.incCounter(data.View, tags, prev, float64(d.Value))
+		case *view.SumData:
+			e.incCounter(data.View, tags, prev, d.Value)
+		case *view.LastValueData:
+			e.factory.Gauge(metrics.Options{
+				Name: data.View.Name,
+				Tags: tags,
+				Help: data.View.Description,
+			}).Update(int64(d.Value))
+		case *view.DistributionData:
+			e.recordDistribution(data.View, tags, prev, d)
+		}
+	}
+}
+
+func (e *ViewExporter) incCounter(v *view.View, tags map[string]string, prev *previousRow, value float64) {
+	// the increment is computed on truncated values so that fractions add up over time
+	delta := int64(value) - int64(prev.value)
+	if value < prev.value {
+		// the view was reset, e.g. unregistered and registered again
+		delta = int64(value)
+	}
+	prev.value = value
+	counter := e.factory.Counter(metrics.Options{
+		Name: v.Name,
+		Tags: tags,
+		Help: v.Description,
+	})
+	if delta > 0 {
+		counter.Inc(delta)
+	}
+}
+
+func (e *ViewExporter) recordDistribution(v *view.View, tags map[string]string, prev *previousRow, d *view.DistributionData) {
+	bounds := v.Aggregation.Buckets
+	histogram := e.factory.Histogram(metrics.HistogramOptions{
+		Name:    v.Name,
+		Tags:    tags,
+		Help:    v.Description,
+		Buckets: bounds,
+	})
+	if len(prev.countPerBucket) != len(d.CountPerBucket) {
+		prev.countPerBucket = make([]int64, len(d.CountPerBucket))
+	}
+	for i, count := range d.CountPerBucket {
+		delta := count - prev.countPerBucket[i]
+		if delta < 0 {
+			// the view was reset
+			delta = count
+		}
+		prev.countPerBucket[i] = count
+		if delta == 0 {
+			continue
+		}
+		value := d.Max
+		if i < len(bounds) {
+			value = bounds[i]
+		} else if len(bounds) > 0 && value <= bounds[len(bounds)-1] {
+			// OpenCensus buckets include their lower bound, the value must be above it
+			value = math.Nextafter(bounds[len(bounds)-1], math.Inf(1))
+		}
+		histogram.Record(value)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+	jprom "github.com/uber/jaeger-lib/metrics/prometheus"
+)
+
+var _ view.Exporter = new(ViewExporter)
+
+func TestViewExporter(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	e := NewViewExporter(f)
+
+	countView := &view.View{Name: "count", Aggregation: view.Count()}
+	sumView := &view.View{Name: "sum", Aggregation: view.Sum()}
+	lastValueView := &view.View{Name: "last", Aggregation: view.LastValue()}
+	method := tag.MustNewKey("method")
+
+	e.ExportView(&view.Data{View: countView, Rows: []*view.Row{
+		{Tags: []tag.Tag{{Key: method, Value: "GET"}}, Data: &view.CountData{Value: 3}},
+		{Tags: []tag.Tag{{Key: method, Value: "PUT"}}, Data: &view.CountData{Value: 1}},
+	}})
+	e.ExportView(&view.Data{View: sumView, Rows: []*view.Row{{Data: &view.SumData{Value: 1.5}}}})
+	e.ExportView(&view.Data{View: lastValueView, Rows: []*view.Row{{Data: &view.LastValueData{Value: 7}}}})
+
+	f.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "count", Tags: map[string]string{"method": "GET"}, Value: 3},
+		metricstest.ExpectedMetric{Name: "count", Tags: map[string]string{"method": "PUT"}, Value: 1},
+		metricstest.ExpectedMetric{Name: "sum", Value: 1},
+	)
+	f.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "last", Value: 7})
+
+	// the views are cumulative, the counters are incremented by the difference
+	e.ExportView(&view.Data{View: countView, Rows: []*view.Row{
+		{Tags: []tag.Tag{{Key: method, Value: "GET"}}, Data: &view.CountData{Value: 5}},
+	}})
+	e.ExportView(&view.Data{View: sumView, Rows: []*view.Row{{Data: &view.SumData{Value: 3.25}}}})
+	f.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "count", Tags: map[string]string{"method": "GET"}, Value: 5},
+		metricstest.ExpectedMetric{Name: "sum", Value: 3},
+	)
+
+	// a reset view starts over
+	e.ExportView(&view.Data{View: countView, Rows: []*view.Row{
+		{Tags: []tag.Tag{{Key: method, Value: "GET"}}, Data: &view.CountData{Value: 2}},
+	}})
+	f.AssertCounterMetrics(t,
+		metricstest.ExpectedMetric{Name: "count", Tags: map[string]string{"method": "GET"}, Value: 7},
+	)
+}
+
+func TestViewExporterDistribution(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	e := NewViewExporter(jprom.New(jprom.WithRegisterer(registry)))
+	distView := &view.View{Name: "latency", Aggregation: view.Distribution(10, 100)}
+
+	e.ExportView(&view.Data{View: distView, Rows: []*view.Row{{Data: &view.DistributionData{
+		Count:          4,
+		Max:            100,
+		CountPerBucket: []int64{1, 2, 1},
+	}}}})
+	e.ExportView(&view.Data{View: distView, Rows: []*view.Row{{Data: &view.DistributionData{
+		Count:          5,
+		Max:            250,
+		CountPerBucket: []int64{1, 2, 2},
+	}}}})
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	h := families[0].GetMetric()[0].GetHistogram()
+	assert.EqualValues(t, 4, h.GetSampleCount(), "one value per bucket that changed")
+	require.Len(t, h.GetBucket(), 2)
+	assert.EqualValues(t, 1, h.GetBucket()[0].GetCumulativeCount())
+	assert.EqualValues(t, 2, h.GetBucket()[1].GetCumulativeCount())
+}
+
+// TestRoundTrip registers a ViewExporter with a meter fed by a Factory.
+func TestRoundTrip(t *testing.T) {
+	meter := newMeter(t)
+	meter.SetReportingPeriod(time.Millisecond)
+	target := metricstest.NewFactory(0)
+	defer target.Stop()
+	meter.RegisterExporter(NewViewExporter(target))
+
+	f := New(WithMeter(meter))
+	f.Counter(metrics.Options{Name: "counter", Tags: map[string]string{"x": "y"}}).Inc(3)
+
+	for i := 0; i < 1000; i++ {
+		counters, _ := target.Snapshot()
+		if counters["counter|x=y"] == 3 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	target.AssertCounterMetrics(t, metricstest.ExpectedMetric{Name: "counter", Tags: map[string]string{"x": "y"}, Value: 3})
+}
+
+func TestViewExporterWithOpenCensusMeasures(t *testing.T) {
+	meter := newMeter(t)
+	measure := stats.Int64("oc_requests", "Requests", stats.UnitDimensionless)
+	require.NoError(t, meter.Register(&view.View{Name: "oc_requests", Measure: measure, Aggregation: view.Count()}))
+	require.NoError(t, stats.RecordWithOptions(context.Background(),
+		stats.WithRecorder(meter), stats.WithMeasurements(measure.M(1), measure.M(1))))
+
+	rows, err := meter.RetrieveData("oc_requests")
+	require.NoError(t, err)
+	target := metricstest.NewFactory(0)
+	defer target.Stop()
+	NewViewExporter(target).ExportView(&view.Data{View: meter.Find("oc_requests"), Rows: rows})
+	target.AssertCounterMetrics(t, metricstest.ExpectedMetric{Name: "oc_requests", Value: 2})
+}
@@ -0,0 +1,277 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/adapters"
+)
+
+var (
+	// DefaultTimerBuckets are the bucket boundaries, in milliseconds, of the views
+	// of Timers created without buckets.
+	DefaultTimerBuckets = []float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+	// DefaultBuckets are the bucket boundaries of the views of Histograms created without buckets.
+	DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+)
+
+// Factory implements metrics.Factory backed by OpenCensus measures and views,
+// so that the metrics are reported by the registered OpenCensus exporters.
+//
+// Each metric is recorded to a measure and registered as a view with the same name:
+// Counters with a Sum aggregation, Gauges with a LastValue aggregation, and Timers
+// and Histograms with a Distribution aggregation. Timers are recorded in milliseconds,
+// the usual unit of OpenCensus latencies. Tags become the tag keys of the views,
+// so all the metrics sharing a name must have the same tag keys.
+type Factory struct {
+	metrics.Factory
+}
+
+type options struct {
+	meter        view.Meter
+	scopeSep     string
+	errorHandler func(error)
+}
+
+// Option is a function that sets some option for the Factory constructor.
+type Option func(*options)
+
+// WithMeter returns an option that sets the meter the views are registered with and
+// the measurements are recorded to. If not used, we fallback to the global meter
+// behind view.Register and stats.Record.
+func WithMeter(meter view.Meter) Option {
+	return func(opts *options) {
+		opts.meter = meter
+	}
+}
+
+// WithScopeSeparator returns an option that sets the separator between namespace
+// names and metric names. If not used, we fallback to ".".
+func WithScopeSeparator(separator string) Option {
+	return func(opts *options) {
+		opts.scopeSep = separator
+	}
+}
+
+// WithErrorHandler returns an option that sets a function called with errors registering
+// views or recording measurements, e.g. because of an invalid tag. If not used, such
+// errors are dropped.
+func WithErrorHandler(handler func(error)) Option {
+	return func(opts *options) {
+		opts.errorHandler = handler
+	}
+}
+
+// New creates a Factory backed by OpenCensus.
+func New(opts ...Option) *Factory {
+	options := &options{
+		errorHandler: func(error) {},
+	}
+	for _, o := range opts {
+		o(options)
+	}
+	return &Factory{
+		Factory: adapters.WrapFactoryWithTags(
+			&factory{
+				meter:        options.meter,
+				errorHandler: options.errorHandler,
+			},
+			adapters.Options{ScopeSep: options.scopeSep},
+		),
+	}
+}
+
+// factory implements adapters.FactoryWithTags
+type factory struct {
+	meter        view.Meter
+	errorHandler func(error)
+}
+
+// register registers the view and checks that a view already registered with the same
+// name has the same tag keys, since OpenCensus accepts such a view but silently drops
+// the tags the registered view does not know about.
+func (f *factory) register(v *view.View) error {
+	register, find := view.Register, view.Find
+	if f.meter != nil {
+		register, find = f.meter.Register, f.meter.Find
+	}
+	if err := register(v); err != nil {
+		return err
+	}
+	if registered := find(v.Name); registered != nil && !sameKeys(registered.TagKeys, v.TagKeys) {
+		return fmt.Errorf("opencensus: view %q is already registered with tag keys %v, not %v", v.Name, registered.TagKeys, v.TagKeys)
+	}
+	return nil
+}
+
+func sameKeys(a, b []tag.Key) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// newMetric registers the view of a metric and returns the metric recording to it,
+// or nil if the view cannot be registered.
+func (f *factory) newMetric(measure stats.Measure, aggregation *view.Aggregation, tags map[string]string) *metric {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	m := &metric{
+		meter:        f.meter,
+		errorHandler: f.errorHandler,
+	}
+	tagKeys := make([]tag.Key, 0, len(keys))
+	for _, k := range keys {
+		key, err := tag.NewKey(k)
+		if err != nil {
+			f.errorHandler(err)
+			return nil
+		}
+		tagKeys = append(tagKeys, key)
+		m.mutators = append(m.mutators, tag.Upsert(key, tags[k]))
+	}
+	err := f.register(&view.View{
+		Name:        measure.Name(),
+		Description: measure.Description(),
+		Measure:     measure,
+		Aggregation: aggregation,
+		TagKeys:     tagKeys,
+	})
+	if err != nil {
+		f.errorHandler(err)
+		return nil
+	}
+	return m
+}
+
+func (f *factory) Counter(options metrics.Options) metrics.Counter {
+	measure := stats.Int64(options.Name, options.Help, stats.UnitDimensionless)
+	m := f.newMetric(measure, view.Sum(), options.Tags)
+	if m == nil {
+		return metrics.NullCounter
+	}
+	return &counter{metric: m, measure: measure}
+}
+
+func (f *factory) Gauge(options metrics.Options) metrics.Gauge {
+	measure := stats.Int64(options.Name, options.Help, stats.UnitDimensionless)
+	m := f.newMetric(measure, view.LastValue(), options.Tags)
+	if m == nil {
+		return metrics.NullGauge
+	}
+	return &gauge{metric: m, measure: measure}
+}
+
+func (f *factory) Timer(options metrics.TimerOptions) metrics.Timer {
+	bounds := DefaultTimerBuckets
+	if len(options.Buckets) > 0 {
+		bounds = make([]float64, len(options.Buckets))
+		for i, b := range options.Buckets {
+			bounds[i] = float64(b) / float64(time.Millisecond)
+		}
+	}
+	measure := stats.Float64(options.Name, options.Help, stats.UnitMilliseconds)
+	m := f.newMetric(measure, view.Distribution(bounds...), options.Tags)
+	if m == nil {
+		return metrics.NullTimer
+	}
+	return &timer{metric: m, measure: measure}
+}
+
+func (f *factory) Histogram(options metrics.HistogramOptions) metrics.Histogram {
+	bounds := DefaultBuckets
+	if len(options.Buckets) > 0 {
+		bounds = options.Buckets
+	}
+	measure := stats.Float64(options.Name, options.Help, stats.UnitDimensionless)
+	m := f.newMetric(measure, view.Distribution(bounds...), options.Tags)
+	if m == nil {
+		return metrics.NullHistogram
+	}
+	return &histogram{metric: m, measure: measure}
+}
+
+type metric struct {
+	meter        view.Meter
+	mutators     []tag.Mutator
+	errorHandler func(error)
+}
+
+func (m *metric) record(measurement stats.Measurement) {
+	opts := []stats.Options{
+		stats.WithTags(m.mutators...),
+		stats.WithMeasurements(measurement),
+	}
+	if m.meter != nil {
+		opts = append(opts, stats.WithRecorder(m.meter))
+	}
+	if err := stats.RecordWithOptions(context.Background(), opts...); err != nil {
+		m.errorHandler(err)
+	}
+}
+
+type counter struct {
+	*metric
+	measure *stats.Int64Measure
+}
+
+func (c *counter) Inc(delta int64) {
+	c.record(c.measure.M(delta))
+}
+
+type gauge struct {
+	*metric
+	measure *stats.Int64Measure
+}
+
+func (g *gauge) Update(value int64) {
+	g.record(g.measure.M(value))
+}
+
+type timer struct {
+	*metric
+	measure *stats.Float64Measure
+}
+
+func (t *timer) Record(d time.Duration) {
+	t.record(t.measure.M(float64(d) / float64(time.Millisecond)))
+}
+
+type histogram struct {
+	*metric
+	measure *stats.Float64Measure
+}
+
+func (h *histogram) Record(v float64) {
+	h.record(h.measure.M(v))
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+var _ metrics.Factory = new(Factory)
+
+func newMeter(t *testing.T) view.Meter {
+	meter := view.NewMeter()
+	meter.Start()
+	t.Cleanup(meter.Stop)
+	return meter
+}
+
+func retrieveRow(t *testing.T, meter view.Meter, name string) *view.Row {
+	rows, err := meter.RetrieveData(name)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	return rows[0]
+}
+
+func TestFactory(t *testing.T) {
+	meter := newMeter(t)
+	f := New(WithMeter(meter))
+	ns := f.Namespace(metrics.NSOptions{Name: "query", Tags: map[string]string{"a": "b"}})
+
+	c := ns.Counter(metrics.Options{Name: "requests", Tags: map[string]string{"result": "ok"}, Help: "Requests"})
+	c.Inc(3)
+	c.Inc(4)
+	g := ns.Gauge(metrics.Options{Name: "queue-length"})
+	g.Update(1)
+	g.Update(42)
+	ns.Timer(metrics.TimerOptions{
+		Name:    "latency",
+		Buckets: []time.Duration{time.Millisecond, time.Second},
+	}).Record(500 * time.Millisecond)
+	ns.Histogram(metrics.HistogramOptions{Name: "size"}).Record(20)
+
+	row := retrieveRow(t, meter, "query.requests")
+	assert.Equal(t, []tag.Tag{
+		{Key: tag.MustNewKey("a"), Value: "b"},
+		{Key: tag.MustNewKey("result"), Value: "ok"},
+	}, row.Tags)
+	assert.EqualValues(t, 7, row.Data.(*view.SumData).Value)
+	assert.Equal(t, "Requests", meter.Find("query.requests").Description)
+
+	row = retrieveRow(t, meter, "query.queue-length")
+	assert.EqualValues(t, 42, row.Data.(*view.LastValueData).Value)
+
+	latency := meter.Find("query.latency")
+	assert.Equal(t, []float64{1, 1000}, latency.Aggregation.Buckets)
+	dist := retrieveRow(t, meter, "query.latency").Data.(*view.DistributionData)
+	assert.EqualValues(t, 1, dist.Count)
+	assert.EqualValues(t, 500, dist.Mean)
+	assert.Equal(t, []int64{0, 1, 0}, dist.CountPerBucket)
+
+	assert.Equal(t, DefaultBuckets, meter.Find("query.size").Aggregation.Buckets)
+	dist = retrieveRow(t, meter, "query.size").Data.(*view.DistributionData)
+	assert.EqualValues(t, 20, dist.Max)
+}
+
+func TestFactoryDifferentTagKeys(t *testing.T) {
+	var errs []error
+	meter := newMeter(t)
+	f := New(WithMeter(meter), WithErrorHandler(func(err error) { errs = append(errs, err) }))
+
+	f.Counter(metrics.Options{Name: "counter", Tags: map[string]string{"x": "1"}}).Inc(1)
+	f.Counter(metrics.Options{Name: "counter", Tags: map[string]string{"x": "2"}}).Inc(2)
+	assert.Empty(t, errs)
+	rows, err := meter.RetrieveData("counter")
+	require.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	c := f.Counter(metrics.Options{Name: "counter", Tags: map[string]string{"y": "1"}})
+	assert.Equal(t, metrics.NullCounter, c, "a view with other tag keys cannot be registered")
+	assert.Len(t, errs, 1)
+}
+
+func TestFactoryInvalidTag(t *testing.T) {
+	var errs []error
+	f := New(WithMeter(newMeter(t)), WithErrorHandler(func(err error) { errs = append(errs, err) }))
+	f.Gauge(metrics.Options{Name: "gauge", Tags: map[string]string{"": "x"}}).Update(1)
+	assert.Len(t, errs, 1)
+}
+
+func TestFactoryScopeSeparator(t *testing.T) {
+	meter := newMeter(t)
+	f := New(WithMeter(meter), WithScopeSeparator("_"))
+	f.Namespace(metrics.NSOptions{Name: "ns"}).Counter(metrics.Options{Name: "counter"}).Inc(1)
+	assert.NotNil(t, meter.Find("ns_counter"))
+}
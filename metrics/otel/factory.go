@@ -0,0 +1,206 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otel
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/adapters"
+)
+
+// Factory implements metrics.Factory backed by an OpenTelemetry Meter.
+//
+// Counters are mapped to Int64Counter, Gauges to Int64Gauge, and Timers and Histograms
+// to Float64Histogram with the buckets of the metric as explicit bucket boundaries.
+// Timers are recorded in seconds, as recommended by the OpenTelemetry semantic conventions.
+// Tags become attributes, and Namespace names become prefixes of the instrument names,
+// e.g. "query.requests".
+type Factory struct {
+	metrics.Factory
+}
+
+type options struct {
+	scopeSep     string
+	errorHandler func(error)
+}
+
+// Option is a function that sets some option for the Factory constructor.
+type Option func(*options)
+
+// WithScopeSeparator returns an option that sets the separator between namespace
+// names and metric names. If not used, we fallback to ".".
+func WithScopeSeparator(separator string) Option {
+	return func(opts *options) {
+		opts.scopeSep = separator
+	}
+}
+
+// WithErrorHandler returns an option that sets a function called with errors creating
+// instruments, e.g. because of an invalid name. If not used, we fallback to otel.Handle.
+func WithErrorHandler(handler func(error)) Option {
+	return func(opts *options) {
+		opts.errorHandler = handler
+	}
+}
+
+// New creates a Factory creating instruments with the meter.
+func New(meter metric.Meter, opts ...Option) *Factory {
+	options := &options{
+		errorHandler: otel.Handle,
+	}
+	for _, o := range opts {
+		o(options)
+	}
+	return &Factory{
+		Factory: adapters.WrapFactoryWithTags(
+			&factory{
+				meter:        meter,
+				errorHandler: options.errorHandler,
+			},
+			adapters.Options{ScopeSep: options.scopeSep},
+		),
+	}
+}
+
+// factory implements adapters.FactoryWithTags
+type factory struct {
+	meter        metric.Meter
+	errorHandler func(error)
+}
+
+// attributes converts the tags into a measurement option, with the attribute set
+// computed once rather than on every measurement.
+func attributes(tags map[string]string) metric.MeasurementOption {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kvs := make([]attribute.KeyValue, len(keys))
+	for i, k := range keys {
+		kvs[i] = attribute.String(k, tags[k])
+	}
+	return metric.WithAttributeSet(attribute.NewSet(kvs...))
+}
+
+func (f *factory) Counter(options metrics.Options) metrics.Counter {
+	c, err := f.meter.Int64Counter(options.Name, metric.WithDescription(options.Help))
+	if err != nil {
+		f.errorHandler(err)
+	}
+	if c == nil {
+		return metrics.NullCounter
+	}
+	return &counter{counter: c, attributes: attributes(options.Tags)}
+}
+
+func (f *factory) Gauge(options metrics.Options) metrics.Gauge {
+	g, err := f.meter.Int64Gauge(options.Name, metric.WithDescription(options.Help))
+	if err != nil {
+		f.errorHandler(err)
+	}
+	if g == nil {
+		return metrics.NullGauge
+	}
+	return &gauge{gauge: g, attributes: attributes(options.Tags)}
+}
+
+func (f *factory) Timer(options metrics.TimerOptions) metrics.Timer {
+	opts := []metric.Float64HistogramOption{
+		metric.WithDescription(options.Help),
+		metric.WithUnit("s"),
+	}
+	if len(options.Buckets) > 0 {
+		opts = append(opts, metric.WithExplicitBucketBoundaries(asSeconds(options.Buckets)...))
+	}
+	h, err := f.meter.Float64Histogram(options.Name, opts...)
+	if err != nil {
+		f.errorHandler(err)
+	}
+	if h == nil {
+		return metrics.NullTimer
+	}
+	return &timer{histogram: h, attributes: attributes(options.Tags)}
+}
+
+func asSeconds(buckets []time.Duration) []float64 {
+	bounds := make([]float64, len(buckets))
+	for i, b := range buckets {
+		bounds[i] = b.Seconds()
+	}
+	return bounds
+}
+
+func (f *factory) Histogram(options metrics.HistogramOptions) metrics.Histogram {
+	opts := []metric.Float64HistogramOption{
+		metric.WithDescription(options.Help),
+	}
+	if len(options.Buckets) > 0 {
+		opts = append(opts, metric.WithExplicitBucketBoundaries(options.Buckets...))
+	}
+	h, err := f.meter.Float64Histogram(options.Name, opts...)
+	if err != nil {
+		f.errorHandler(err)
+	}
+	if h == nil {
+		return metrics.NullHistogram
+	}
+	return &histogram{histogram: h, attributes: attributes(options.Tags)}
+}
+
+type counter struct {
+	counter    metric.Int64Counter
+	attributes metric.MeasurementOption
+}
+
+// Inc adds the delta to the counter. OpenTelemetry counters are monotonic,
+// so negative deltas are dropped by the SDK.
+func (c *counter) Inc(delta int64) {
+	c.counter.Add(context.Background(), delta, c.attributes)
+}
+
+type gauge struct {
+	gauge      metric.Int64Gauge
+	attributes metric.MeasurementOption
+}
+
+func (g *gauge) Update(value int64) {
+	g.gauge.Record(context.Background(), value, g.attributes)
+}
+
+type timer struct {
+	histogram  metric.Float64Histogram
+	attributes metric.MeasurementOption
+}
+
+func (t *timer) Record(d time.Duration) {
+	t.histogram.Record(context.Background(), d.Seconds(), t.attributes)
+}
+
+type histogram struct {
+	histogram  metric.Float64Histogram
+	attributes metric.MeasurementOption
+}
+
+func (h *histogram) Record(v float64) {
+	h.histogram.Record(context.Background(), v, h.attributes)
+}
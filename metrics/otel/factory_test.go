@@ -0,0 +1,127 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+var _ metrics.Factory = new(Factory)
+
+func newTestFactory(t *testing.T, opts ...Option) (*Factory, *sdkmetric.ManualReader) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return New(provider.Meter("jaeger-lib"), opts...), reader
+}
+
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Metrics {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	result := make(map[string]metricdata.Metrics)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			result[m.Name] = m
+		}
+	}
+	return result
+}
+
+func TestFactory(t *testing.T) {
+	f, reader := newTestFactory(t)
+	ns := f.Namespace(metrics.NSOptions{Name: "query", Tags: map[string]string{"a": "b"}})
+
+	c := ns.Counter(metrics.Options{Name: "requests", Tags: map[string]string{"result": "ok"}, Help: "Requests"})
+	c.Inc(3)
+	c.Inc(4)
+	ns.Gauge(metrics.Options{Name: "queue-length"}).Update(42)
+	ns.Timer(metrics.TimerOptions{
+		Name:    "latency",
+		Buckets: []time.Duration{time.Millisecond, time.Second},
+	}).Record(500 * time.Millisecond)
+	ns.Histogram(metrics.HistogramOptions{
+		Name:    "size",
+		Buckets: []float64{1, 10},
+	}).Record(20)
+
+	collected := collect(t, reader)
+
+	requests := collected["query.requests"]
+	assert.Equal(t, "Requests", requests.Description)
+	sum := requests.Data.(metricdata.Sum[int64])
+	assert.True(t, sum.IsMonotonic)
+	require.Len(t, sum.DataPoints, 1)
+	assert.EqualValues(t, 7, sum.DataPoints[0].Value)
+	assert.Equal(t,
+		attribute.NewSet(attribute.String("a", "b"), attribute.String("result", "ok")),
+		sum.DataPoints[0].Attributes)
+
+	gauge := collected["query.queue-length"].Data.(metricdata.Gauge[int64])
+	require.Len(t, gauge.DataPoints, 1)
+	assert.EqualValues(t, 42, gauge.DataPoints[0].Value)
+
+	latency := collected["query.latency"]
+	assert.Equal(t, "s", latency.Unit)
+	timer := latency.Data.(metricdata.Histogram[float64])
+	require.Len(t, timer.DataPoints, 1)
+	assert.Equal(t, []float64{0.001, 1}, timer.DataPoints[0].Bounds)
+	assert.Equal(t, []uint64{0, 1, 0}, timer.DataPoints[0].BucketCounts)
+	assert.EqualValues(t, 0.5, timer.DataPoints[0].Sum)
+
+	histogram := collected["query.size"].Data.(metricdata.Histogram[float64])
+	require.Len(t, histogram.DataPoints, 1)
+	assert.Equal(t, []float64{1, 10}, histogram.DataPoints[0].Bounds)
+	assert.Equal(t, []uint64{0, 0, 1}, histogram.DataPoints[0].BucketCounts)
+	assert.Equal(t,
+		attribute.NewSet(attribute.String("a", "b")),
+		histogram.DataPoints[0].Attributes)
+}
+
+func TestFactoryDefaultBuckets(t *testing.T) {
+	f, reader := newTestFactory(t)
+	f.Timer(metrics.TimerOptions{Name: "timer"}).Record(time.Second)
+
+	timer := collect(t, reader)["timer"].Data.(metricdata.Histogram[float64])
+	require.Len(t, timer.DataPoints, 1)
+	assert.NotEmpty(t, timer.DataPoints[0].Bounds, "the SDK default boundaries are used")
+}
+
+func TestFactoryTagsOverrideNamespaceTags(t *testing.T) {
+	f, reader := newTestFactory(t, WithScopeSeparator("_"))
+	f.Namespace(metrics.NSOptions{Name: "ns", Tags: map[string]string{"x": "ns"}}).
+		Counter(metrics.Options{Name: "counter", Tags: map[string]string{"x": "metric"}}).
+		Inc(1)
+
+	sum := collect(t, reader)["ns_counter"].Data.(metricdata.Sum[int64])
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, attribute.NewSet(attribute.String("x", "metric")), sum.DataPoints[0].Attributes)
+}
+
+func TestFactoryInvalidName(t *testing.T) {
+	var errs []error
+	f, _ := newTestFactory(t, WithErrorHandler(func(err error) { errs = append(errs, err) }))
+	f.Counter(metrics.Options{Name: "invalid name!"}).Inc(1)
+	assert.NotEmpty(t, errs)
+}
@@ -0,0 +1,339 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// instrumentationScope is the name of the instrumentation scope of the exported metrics.
+const instrumentationScope = "github.com/uber/jaeger-lib/metrics/otlp"
+
+const (
+	defaultExportInterval = 60 * time.Second
+	defaultExportTimeout  = 10 * time.Second
+	defaultRetries        = 3
+	defaultBackoff        = 100 * time.Millisecond
+	defaultMaxBackoff     = 5 * time.Second
+)
+
+// Temporality is the aggregation temporality of the exported Counters, Timers and Histograms.
+type Temporality int
+
+const (
+	// CumulativeTemporality exports the values accumulated since the Factory was created.
+	CumulativeTemporality Temporality = iota
+
+	// DeltaTemporality exports the changes since the previous successful export.
+	DeltaTemporality
+)
+
+// proto returns the value of the AggregationTemporality enum.
+func (t Temporality) proto() int {
+	if t == DeltaTemporality {
+		return 1
+	}
+	return 2
+}
+
+// Exporter periodically sends the metrics aggregated by a Factory to an OTLP/HTTP
+// endpoint as a protobuf ExportMetricsServiceRequest.
+type Exporter struct {
+	factory  *Factory
+	endpoint string
+	options  exporterOptions
+	lock     sync.Mutex
+	previous map[string]pointData
+	lastTime time.Time
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	once     sync.Once
+	timeNow  func() time.Time
+}
+
+type exporterOptions struct {
+	interval     time.Duration
+	temporality  Temporality
+	resource     []attribute
+	headers      map[string]string
+	retries      int
+	backoff      time.Duration
+	maxBackoff   time.Duration
+	client       *http.Client
+	errorHandler func(error)
+}
+
+// ExporterOption is a function that sets some option for the Exporter constructor.
+type ExporterOption func(*exporterOptions)
+
+// WithExportInterval returns an option that sets how often the metrics are exported.
+// If not used, we fallback to 60 seconds. A non-positive interval disables the periodic
+// exports, Export must be called instead.
+func WithExportInterval(interval time.Duration) ExporterOption {
+	return func(opts *exporterOptions) {
+		opts.interval = interval
+	}
+}
+
+// WithTemporality returns an option that sets the aggregation temporality of the exported
+// Counters, Timers and Histograms. If not used, we fallback to CumulativeTemporality.
+func WithTemporality(temporality Temporality) ExporterOption {
+	return func(opts *exporterOptions) {
+		opts.temporality = temporality
+	}
+}
+
+// WithResourceAttributes returns an option that sets the attributes of the resource
+// producing the metrics, e.g. "service.name".
+func WithResourceAttributes(attributes map[string]string) ExporterOption {
+	return func(opts *exporterOptions) {
+		opts.resource = sortedAttributes(attributes)
+	}
+}
+
+// WithHeader returns an option that adds an HTTP header to every request,
+// e.g. for authentication.
+func WithHeader(name, value string) ExporterOption {
+	return func(opts *exporterOptions) {
+		opts.headers[name] = value
+	}
+}
+
+// WithRetries returns an option that sets how many times a request failing with
+// a network error or a retryable status (429, 502, 503 and 504) is retried, and the
+// backoff between attempts, which doubles after each attempt up to maxBackoff.
+// If not used, a request is retried 3 times starting with a 100ms backoff of up to 5 seconds.
+func WithRetries(retries int, backoff, maxBackoff time.Duration) ExporterOption {
+	return func(opts *exporterOptions) {
+		opts.retries = retries
+		opts.backoff = backoff
+		opts.maxBackoff = maxBackoff
+	}
+}
+
+// WithHTTPClient returns an option that sets the HTTP client used for sending.
+// If not used, we fallback to a client with a 10 seconds timeout.
+func WithHTTPClient(client *http.Client) ExporterOption {
+	return func(opts *exporterOptions) {
+		opts.client = client
+	}
+}
+
+// WithErrorHandler returns an option that sets a function called with errors of the
+// periodic exports. If not used, such errors are dropped.
+func WithErrorHandler(handler func(error)) ExporterOption {
+	return func(opts *exporterOptions) {
+		opts.errorHandler = handler
+	}
+}
+
+func applyExporterOptions(opts []ExporterOption) exporterOptions {
+	options := exporterOptions{
+		interval:     defaultExportInterval,
+		headers:      make(map[string]string),
+		retries:      defaultRetries,
+		backoff:      defaultBackoff,
+		maxBackoff:   defaultMaxBackoff,
+		errorHandler: func(error) {},
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+	if options.client == nil {
+		options.client = &http.Client{Timeout: defaultExportTimeout}
+	}
+	return options
+}
+
+// NewExporter creates an Exporter sending the metrics of the factory to the endpoint,
+// e.g. "http://localhost:4318/v1/metrics", and starts it.
+func NewExporter(factory *Factory, endpoint string, opts ...ExporterOption) *Exporter {
+	e := &Exporter{
+		factory:  factory,
+		endpoint: endpoint,
+		options:  applyExporterOptions(opts),
+		previous: make(map[string]pointData),
+		lastTime: factory.startTime,
+		stop:     make(chan struct{}),
+		timeNow:  time.Now,
+	}
+	if e.options.interval > 0 {
+		e.wg.Add(1)
+		go e.exportLoop()
+	}
+	return e
+}
+
+func (e *Exporter) exportLoop() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(e.options.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := e.Export(); err != nil {
+				e.options.errorHandler(err)
+			}
+		case <-e.stop:
+			return
+		}
+	}
+}
+
+// Close stops the periodic exports and exports the metrics one last time.
+// Failed exports are no longer retried once closing.
+func (e *Exporter) Close() error {
+	var err error
+	e.once.Do(func() {
+		close(e.stop)
+		e.wg.Wait()
+		err = e.Export()
+	})
+	return err
+}
+
+// Export sends the current metrics. With DeltaTemporality, the values that could
+// not be sent are included in the next export.
+func (e *Exporter) Export() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	now := e.timeNow()
+	snapshot := e.factory.snapshot()
+	request := exportRequest{
+		resource:    e.options.resource,
+		temporality: e.options.temporality,
+		startTime:   e.factory.startTime.UnixNano(),
+		time:        now.UnixNano(),
+		metrics:     snapshot,
+	}
+	if e.options.temporality == DeltaTemporality {
+		request.startTime = e.lastTime.UnixNano()
+		request.metrics = e.delta(snapshot)
+	}
+	if err := e.sendWithRetries(request.encode()); err != nil {
+		return err
+	}
+	if e.options.temporality == DeltaTemporality {
+		e.lastTime = now
+		for _, m := range snapshot {
+			for _, p := range m.points {
+				e.previous[previousKey(m, p)] = p
+			}
+		}
+	}
+	return nil
+}
+
+func previousKey(m metricData, p pointData) string {
+	return fmt.Sprintf("%d|%s|%s", m.kind, m.name, p.key)
+}
+
+// delta subtracts the values of the previous successful export from the cumulative
+// values of the snapshot. Gauges are not affected.
+func (e *Exporter) delta(snapshot []metricData) []metricData {
+	result := make([]metricData, len(snapshot))
+	for i, m := range snapshot {
+		result[i] = m
+		if m.kind == kindGauge {
+			continue
+		}
+		result[i].points = make([]pointData, len(m.points))
+		for j, p := range m.points {
+			prev, ok := e.previous[previousKey(m, p)]
+			if !ok {
+				result[i].points[j] = p
+				continue
+			}
+			d := p
+			d.value -= prev.value
+			d.count -= prev.count
+			d.sum -= prev.sum
+			// min and max cannot be computed for the interval
+			d.hasMinMax = false
+			if p.bucketCounts != nil {
+				d.bucketCounts = make([]uint64, len(p.bucketCounts))
+				for k := range p.bucketCounts {
+					d.bucketCounts[k] = p.bucketCounts[k] - prev.bucketCounts[k]
+				}
+			}
+			result[i].points[j] = d
+		}
+	}
+	return result
+}
+
+func (e *Exporter) sendWithRetries(request []byte) error {
+	backoff := e.options.backoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := e.send(request)
+		if err == nil || !retryable || attempt >= e.options.retries {
+			return err
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-e.stop:
+			timer.Stop()
+			return err
+		}
+		backoff *= 2
+		if backoff > e.options.maxBackoff {
+			backoff = e.options.maxBackoff
+		}
+	}
+}
+
+// retryableStatuses are the HTTP statuses the OTLP specification allows to retry.
+var retryableStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// send posts a single request and reports whether a failure can be retried.
+func (e *Exporter) send(request []byte) (retryable bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, e.endpoint, bytes.NewReader(request))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", "jaeger-lib-otlp")
+	for name, value := range e.options.headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := e.options.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	err = fmt.Errorf("OTLP endpoint returned HTTP status %s", resp.Status)
+	for _, status := range retryableStatuses {
+		if resp.StatusCode == status {
+			return true, err
+		}
+	}
+	return false, err
+}
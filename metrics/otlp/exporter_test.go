@@ -0,0 +1,457 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+var _ metrics.Factory = new(Factory)
+
+// decodedMetric is the part of an OTLP Metric the tests look at.
+type decodedMetric struct {
+	name, description, unit string
+	kind                    protowire.Number
+	temporality             uint64
+	monotonic               bool
+	points                  []decodedPoint
+}
+
+type decodedPoint struct {
+	attributes   map[string]string
+	startTime    uint64
+	time         uint64
+	value        int64
+	count        uint64
+	sum          float64
+	bucketCounts []uint64
+	bounds       []float64
+	min, max     *float64
+}
+
+type decodedRequest struct {
+	resource map[string]string
+	scope    string
+	metrics  map[string]decodedMetric
+}
+
+// collector is an OTLP/HTTP stand-in decoding the export requests.
+type collector struct {
+	sync.Mutex
+	statuses []int
+	headers  []http.Header
+	requests []decodedRequest
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	c.Lock()
+	defer c.Unlock()
+	c.headers = append(c.headers, r.Header)
+	if len(c.statuses) > 0 {
+		status := c.statuses[0]
+		c.statuses = c.statuses[1:]
+		w.WriteHeader(status)
+		return
+	}
+	c.requests = append(c.requests, decodeRequest(body))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c *collector) lastRequest(t *testing.T) decodedRequest {
+	c.Lock()
+	defer c.Unlock()
+	require.NotEmpty(t, c.requests)
+	return c.requests[len(c.requests)-1]
+}
+
+func forEachField(b []byte, fn func(protowire.Number, protowire.Type, []byte)) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		b = b[n:]
+		n = protowire.ConsumeFieldValue(num, typ, b)
+		value := b[:n]
+		if typ == protowire.BytesType {
+			value, _ = protowire.ConsumeBytes(value)
+		}
+		fn(num, typ, value)
+		b = b[n:]
+	}
+}
+
+func decodeKeyValue(b []byte, into map[string]string) {
+	var key, value string
+	forEachField(b, func(num protowire.Number, _ protowire.Type, v []byte) {
+		if num == keyValueKey {
+			key = string(v)
+			return
+		}
+		forEachField(v, func(_ protowire.Number, _ protowire.Type, s []byte) {
+			value = string(s)
+		})
+	})
+	into[key] = value
+}
+
+func fixed64(b []byte) uint64 {
+	return binary.LittleEndian.Uint64(b)
+}
+
+func decodeRequest(b []byte) decodedRequest {
+	req := decodedRequest{
+		resource: make(map[string]string),
+		metrics:  make(map[string]decodedMetric),
+	}
+	forEachField(b, func(_ protowire.Number, _ protowire.Type, rm []byte) {
+		forEachField(rm, func(num protowire.Number, _ protowire.Type, v []byte) {
+			if num == resourceMetricsResource {
+				forEachField(v, func(_ protowire.Number, _ protowire.Type, kv []byte) {
+					decodeKeyValue(kv, req.resource)
+				})
+				return
+			}
+			forEachField(v, func(num protowire.Number, _ protowire.Type, v []byte) {
+				if num == scopeMetricsScope {
+					forEachField(v, func(_ protowire.Number, _ protowire.Type, name []byte) {
+						req.scope = string(name)
+					})
+					return
+				}
+				m := decodeMetric(v)
+				req.metrics[m.name] = m
+			})
+		})
+	})
+	return req
+}
+
+func decodeMetric(b []byte) decodedMetric {
+	var m decodedMetric
+	forEachField(b, func(num protowire.Number, _ protowire.Type, v []byte) {
+		switch num {
+		case metricName:
+			m.name = string(v)
+		case metricDescription:
+			m.description = string(v)
+		case metricUnit:
+			m.unit = string(v)
+		default:
+			m.kind = num
+			forEachField(v, func(num protowire.Number, _ protowire.Type, v []byte) {
+				switch num {
+				case dataDataPoints:
+					m.points = append(m.points, decodePoint(m.kind, v))
+				case dataTemporality:
+					m.temporality, _ = protowire.ConsumeVarint(v)
+				case sumIsMonotonic:
+					monotonic, _ := protowire.ConsumeVarint(v)
+					m.monotonic = monotonic == 1
+				}
+			})
+		}
+	})
+	return m
+}
+
+func decodePoint(kind protowire.Number, b []byte) decodedPoint {
+	p := decodedPoint{attributes: make(map[string]string)}
+	forEachField(b, func(num protowire.Number, _ protowire.Type, v []byte) {
+		if kind != metricHistogram {
+			switch num {
+			case numberAttributes:
+				decodeKeyValue(v, p.attributes)
+			case numberStartTime:
+				p.startTime = fixed64(v)
+			case numberTime:
+				p.time = fixed64(v)
+			case numberAsInt:
+				p.value = int64(fixed64(v))
+			}
+			return
+		}
+		switch num {
+		case histogramAttributes:
+			decodeKeyValue(v, p.attributes)
+		case histogramStartTime:
+			p.startTime = fixed64(v)
+		case histogramTime:
+			p.time = fixed64(v)
+		case histogramCount:
+			p.count = fixed64(v)
+		case histogramSum:
+			p.sum = math.Float64frombits(fixed64(v))
+		case histogramBucketCounts:
+			for ; len(v) > 0; v = v[8:] {
+				p.bucketCounts = append(p.bucketCounts, fixed64(v))
+			}
+		case histogramExplicitBounds:
+			for ; len(v) > 0; v = v[8:] {
+				p.bounds = append(p.bounds, math.Float64frombits(fixed64(v)))
+			}
+		case histogramMin:
+			min := math.Float64frombits(fixed64(v))
+			p.min = &min
+		case histogramMax:
+			max := math.Float64frombits(fixed64(v))
+			p.max = &max
+		}
+	})
+	return p
+}
+
+func TestExporter(t *testing.T) {
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	f := New()
+	ns := f.Namespace(metrics.NSOptions{Name: "query", Tags: map[string]string{"a": "b"}})
+	ns.Counter(metrics.Options{Name: "requests", Tags: map[string]string{"result": "ok"}, Help: "Requests"}).Inc(3)
+	ns.Gauge(metrics.Options{Name: "queue-length"}).Update(42)
+	ns.Timer(metrics.TimerOptions{
+		Name:    "latency",
+		Buckets: []time.Duration{time.Millisecond, time.Second},
+	}).Record(500 * time.Millisecond)
+	h := ns.Histogram(metrics.HistogramOptions{Name: "size", Buckets: []float64{10, 1}})
+	h.Record(1)
+	h.Record(20)
+
+	e := NewExporter(f, server.URL+"/v1/metrics",
+		WithExportInterval(0),
+		WithResourceAttributes(map[string]string{"service.name": "query"}),
+		WithHeader("Authorization", "Bearer token"),
+	)
+	e.timeNow = func() time.Time { return f.startTime.Add(time.Minute) }
+	require.NoError(t, e.Close())
+
+	req := c.lastRequest(t)
+	assert.Equal(t, map[string]string{"service.name": "query"}, req.resource)
+	assert.Equal(t, instrumentationScope, req.scope)
+	assert.Equal(t, "application/x-protobuf", c.headers[0].Get("Content-Type"))
+	assert.Equal(t, "Bearer token", c.headers[0].Get("Authorization"))
+
+	start := uint64(f.startTime.UnixNano())
+	end := uint64(f.startTime.Add(time.Minute).UnixNano())
+
+	requests := req.metrics["query.requests"]
+	assert.Equal(t, "Requests", requests.description)
+	assert.EqualValues(t, metricSum, requests.kind)
+	assert.EqualValues(t, 2, requests.temporality)
+	assert.True(t, requests.monotonic)
+	require.Len(t, requests.points, 1)
+	assert.Equal(t, decodedPoint{
+		attributes: map[string]string{"a": "b", "result": "ok"},
+		startTime:  start,
+		time:       end,
+		value:      3,
+	}, requests.points[0])
+
+	gauge := req.metrics["query.queue-length"]
+	assert.EqualValues(t, metricGauge, gauge.kind)
+	require.Len(t, gauge.points, 1)
+	assert.EqualValues(t, 42, gauge.points[0].value)
+	assert.Zero(t, gauge.points[0].startTime)
+
+	latency := req.metrics["query.latency"]
+	assert.Equal(t, "s", latency.unit)
+	assert.EqualValues(t, metricHistogram, latency.kind)
+	require.Len(t, latency.points, 1)
+	assert.Equal(t, []float64{0.001, 1}, latency.points[0].bounds)
+	assert.Equal(t, []uint64{0, 1, 0}, latency.points[0].bucketCounts)
+	assert.EqualValues(t, 1, latency.points[0].count)
+	assert.EqualValues(t, 0.5, latency.points[0].sum)
+
+	size := req.metrics["query.size"]
+	require.Len(t, size.points, 1)
+	assert.Equal(t, []float64{1, 10}, size.points[0].bounds)
+	assert.Equal(t, []uint64{1, 0, 1}, size.points[0].bucketCounts, "values equal to a bound are in its bucket")
+	require.NotNil(t, size.points[0].min)
+	assert.EqualValues(t, 1, *size.points[0].min)
+	assert.EqualValues(t, 20, *size.points[0].max)
+}
+
+func TestExporterNonMonotonicCounter(t *testing.T) {
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	f := New()
+	f.Counter(metrics.Options{Name: "balance", Tags: map[string]string{"a": "1"}}).Inc(5)
+	decremented := f.Counter(metrics.Options{Name: "balance", Tags: map[string]string{"a": "2"}})
+	f.Counter(metrics.Options{Name: "requests"}).Inc(1)
+	e := NewExporter(f, server.URL, WithExportInterval(0))
+
+	require.NoError(t, e.Export())
+	assert.True(t, c.lastRequest(t).metrics["balance"].monotonic)
+
+	decremented.Inc(-2)
+	require.NoError(t, e.Export())
+	req := c.lastRequest(t)
+	assert.False(t, req.metrics["balance"].monotonic, "a decremented counter is not monotonic")
+	assert.True(t, req.metrics["requests"].monotonic)
+
+	decremented.Inc(3)
+	require.NoError(t, e.Close())
+	assert.False(t, c.lastRequest(t).metrics["balance"].monotonic, "the sum stays non-monotonic")
+}
+
+func TestExporterDeltaTemporality(t *testing.T) {
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	f := New()
+	counter := f.Counter(metrics.Options{Name: "counter"})
+	histogram := f.Histogram(metrics.HistogramOptions{Name: "histogram", Buckets: []float64{1}})
+	gauge := f.Gauge(metrics.Options{Name: "gauge"})
+
+	e := NewExporter(f, server.URL,
+		WithExportInterval(0),
+		WithTemporality(DeltaTemporality),
+		WithRetries(0, 0, 0),
+	)
+	now := f.startTime
+	e.timeNow = func() time.Time { return now }
+
+	counter.Inc(3)
+	histogram.Record(0.5)
+	gauge.Update(7)
+	now = now.Add(time.Minute)
+	require.NoError(t, e.Export())
+	first := c.lastRequest(t)
+	assert.EqualValues(t, 1, first.metrics["counter"].temporality)
+	assert.EqualValues(t, 3, first.metrics["counter"].points[0].value)
+	assert.EqualValues(t, uint64(f.startTime.UnixNano()), first.metrics["counter"].points[0].startTime)
+
+	// a failed export is included in the next one
+	counter.Inc(4)
+	histogram.Record(2)
+	c.statuses = []int{http.StatusBadRequest}
+	now = now.Add(time.Minute)
+	require.Error(t, e.Export())
+
+	counter.Inc(5)
+	now = now.Add(time.Minute)
+	require.NoError(t, e.Export())
+	second := c.lastRequest(t)
+	p := second.metrics["counter"].points[0]
+	assert.EqualValues(t, 9, p.value)
+	assert.EqualValues(t, uint64(f.startTime.Add(time.Minute).UnixNano()), p.startTime)
+	assert.EqualValues(t, uint64(f.startTime.Add(3*time.Minute).UnixNano()), p.time)
+
+	hp := second.metrics["histogram"].points[0]
+	assert.EqualValues(t, 1, hp.count)
+	assert.EqualValues(t, 2, hp.sum)
+	assert.Equal(t, []uint64{0, 1}, hp.bucketCounts)
+	assert.Nil(t, hp.min, "min and max are unknown for a delta")
+
+	assert.EqualValues(t, 7, second.metrics["gauge"].points[0].value, "gauges are not deltas")
+}
+
+func TestExporterRetries(t *testing.T) {
+	testCases := []struct {
+		name     string
+		statuses []int
+		requests int
+		ok       bool
+	}{
+		{name: "retry on 503", statuses: []int{503, 503}, requests: 3, ok: true},
+		{name: "retry on 429", statuses: []int{429}, requests: 2, ok: true},
+		{name: "no retry on 500", statuses: []int{500}, requests: 1, ok: false},
+		{name: "retries exhausted", statuses: []int{502, 502, 502}, requests: 3, ok: false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := &collector{statuses: testCase.statuses}
+			server := httptest.NewServer(c)
+			defer server.Close()
+
+			f := New()
+			f.Counter(metrics.Options{Name: "counter"}).Inc(1)
+			e := NewExporter(f, server.URL, WithExportInterval(0), WithRetries(2, time.Millisecond, time.Millisecond))
+			defer e.Close()
+			err := e.Export()
+			assert.Equal(t, testCase.ok, err == nil, "%v", err)
+			c.Lock()
+			assert.Len(t, c.headers, testCase.requests)
+			c.Unlock()
+		})
+	}
+}
+
+func TestExporterCloseAbortsRetries(t *testing.T) {
+	c := &collector{statuses: []int{503, 503, 503, 503}}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	f := New()
+	f.Counter(metrics.Options{Name: "counter"}).Inc(1)
+	e := NewExporter(f, server.URL, WithExportInterval(time.Millisecond), WithRetries(3, time.Hour, time.Hour))
+	for i := 0; i < 1000; i++ {
+		c.Lock()
+		n := len(c.headers)
+		c.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	closed := make(chan error)
+	go func() {
+		closed <- e.Close()
+	}()
+	select {
+	case err := <-closed:
+		assert.Error(t, err, "the final export is not retried")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close waited for the retry backoff")
+	}
+}
+
+func TestExporterInterval(t *testing.T) {
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	f := New(WithScopeSeparator("_"))
+	f.Namespace(metrics.NSOptions{Name: "ns"}).Counter(metrics.Options{Name: "counter"}).Inc(1)
+	e := NewExporter(f, server.URL, WithExportInterval(time.Millisecond))
+	defer e.Close()
+
+	for i := 0; i < 1000; i++ {
+		c.Lock()
+		n := len(c.requests)
+		c.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	assert.Contains(t, c.lastRequest(t).metrics, "ns_counter")
+}
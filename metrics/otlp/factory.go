@@ -0,0 +1,339 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/adapters"
+)
+
+var (
+	// DefaultBuckets are the bucket boundaries of Histograms created without buckets,
+	// the same as the OpenTelemetry SDK defaults.
+	DefaultBuckets = []float64{0, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 7500, 10000}
+
+	// DefaultTimerBuckets are the bucket boundaries, in seconds, of Timers created without buckets.
+	DefaultTimerBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+)
+
+type kind int
+
+const (
+	kindSum kind = iota
+	kindGauge
+	kindHistogram
+)
+
+// Factory implements metrics.Factory by aggregating the metrics in memory, so that
+// an Exporter can send them to an OTLP endpoint. Counters are cumulative sums,
+// monotonic unless one of the Counters sharing their name is incremented by a negative delta,
+// Gauges keep the last value, and Timers and Histograms are explicit bucket histograms.
+// Timers are recorded in seconds. Namespace names become prefixes of the metric names,
+// e.g. "query.requests", and tags become attributes.
+type Factory struct {
+	metrics.Factory
+	lock        sync.Mutex
+	instruments map[instrumentKey]*instrument
+	order       []*instrument
+	startTime   time.Time
+}
+
+type options struct {
+	scopeSep string
+}
+
+// Option is a function that sets some option for the Factory constructor.
+type Option func(*options)
+
+// WithScopeSeparator returns an option that sets the separator between namespace
+// names and metric names. If not used, we fallback to ".".
+func WithScopeSeparator(separator string) Option {
+	return func(opts *options) {
+		opts.scopeSep = separator
+	}
+}
+
+// New creates a Factory aggregating metrics in memory.
+func New(opts ...Option) *Factory {
+	options := &options{}
+	for _, o := range opts {
+		o(options)
+	}
+	f := &Factory{
+		instruments: make(map[instrumentKey]*instrument),
+		startTime:   time.Now(),
+	}
+	f.Factory = adapters.WrapFactoryWithTags(&factory{parent: f}, adapters.Options{ScopeSep: options.scopeSep})
+	return f
+}
+
+type instrumentKey struct {
+	name string
+	kind kind
+}
+
+// instrument groups the points of the metrics sharing a name, one per set of attributes.
+type instrument struct {
+	lock        sync.Mutex
+	name        string
+	description string
+	unit        string
+	kind        kind
+	bounds      []float64
+	points      map[string]*point
+	order       []*point
+	decremented int32 // set once a Counter of the instrument is decremented
+}
+
+// getOrCreateInstrument returns the instrument with the name and kind. The description,
+// unit and buckets of the first metric created with the name are used.
+func (f *Factory) getOrCreateInstrument(name, description, unit string, k kind, bounds []float64) *instrument {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	key := instrumentKey{name: name, kind: k}
+	if i, ok := f.instruments[key]; ok {
+		return i
+	}
+	i := &instrument{
+		name:        name,
+		description: description,
+		unit:        unit,
+		kind:        k,
+		bounds:      bounds,
+		points:      make(map[string]*point),
+	}
+	f.instruments[key] = i
+	f.order = append(f.order, i)
+	return i
+}
+
+func (i *instrument) getOrCreatePoint(tags map[string]string) *point {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	key := metrics.GetKey("", tags, "|", "=")
+	if p, ok := i.points[key]; ok {
+		return p
+	}
+	p := &point{
+		key:        key,
+		attributes: sortedAttributes(tags),
+	}
+	if i.kind == kindSum {
+		p.decremented = &i.decremented
+	}
+	if i.kind == kindHistogram {
+		p.bucketCounts = make([]uint64, len(i.bounds)+1)
+	}
+	i.points[key] = p
+	i.order = append(i.order, p)
+	return p
+}
+
+type attribute struct {
+	key, value string
+}
+
+func sortedAttributes(tags map[string]string) []attribute {
+	attributes := make([]attribute, 0, len(tags))
+	for k, v := range tags {
+		attributes = append(attributes, attribute{key: k, value: v})
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].key < attributes[j].key
+	})
+	return attributes
+}
+
+// point holds the aggregated value of a metric with a set of attributes.
+type point struct {
+	value        int64 // first for 64-bit alignment of atomic operations
+	key          string
+	attributes   []attribute
+	decremented  *int32
+	lock         sync.Mutex
+	count        uint64
+	sum          float64
+	min          float64
+	max          float64
+	bucketCounts []uint64
+}
+
+func (p *point) Inc(delta int64) {
+	if delta < 0 {
+		atomic.StoreInt32(p.decremented, 1)
+	}
+	atomic.AddInt64(&p.value, delta)
+}
+
+func (p *point) Update(value int64) {
+	atomic.StoreInt64(&p.value, value)
+}
+
+func (p *point) record(bounds []float64, v float64) {
+	// buckets are upper-inclusive, i.e. a value equal to a bound falls into its bucket
+	bucket := sort.SearchFloat64s(bounds, v)
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.count++
+	p.sum += v
+	if p.count == 1 || v < p.min {
+		p.min = v
+	}
+	if p.count == 1 || v > p.max {
+		p.max = v
+	}
+	p.bucketCounts[bucket]++
+}
+
+// metricData is a snapshot of an instrument.
+type metricData struct {
+	name        string
+	description string
+	unit        string
+	kind        kind
+	monotonic   bool
+	bounds      []float64
+	points      []pointData
+}
+
+// pointData is a snapshot of a point.
+type pointData struct {
+	key          string
+	attributes   []attribute
+	value        int64
+	count        uint64
+	sum          float64
+	min          float64
+	max          float64
+	hasMinMax    bool
+	bucketCounts []uint64
+}
+
+// snapshot returns the current values of all the metrics.
+func (f *Factory) snapshot() []metricData {
+	f.lock.Lock()
+	instruments := f.order
+	f.lock.Unlock()
+	result := make([]metricData, 0, len(instruments))
+	for _, i := range instruments {
+		i.lock.Lock()
+		points := i.order
+		i.lock.Unlock()
+		m := metricData{
+			name:        i.name,
+			description: i.description,
+			unit:        i.unit,
+			kind:        i.kind,
+			monotonic:   i.kind == kindSum && atomic.LoadInt32(&i.decremented) == 0,
+			bounds:      i.bounds,
+			points:      make([]pointData, len(points)),
+		}
+		for j, p := range points {
+			m.points[j] = p.snapshot()
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+func (p *point) snapshot() pointData {
+	data := pointData{
+		key:        p.key,
+		attributes: p.attributes,
+		value:      atomic.LoadInt64(&p.value),
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.bucketCounts != nil {
+		data.count = p.count
+		data.sum = p.sum
+		data.min = p.min
+		data.max = p.max
+		data.hasMinMax = p.count > 0
+		data.bucketCounts = append([]uint64(nil), p.bucketCounts...)
+	}
+	return data
+}
+
+// factory implements adapters.FactoryWithTags
+type factory struct {
+	parent *Factory
+}
+
+func (f *factory) Counter(options metrics.Options) metrics.Counter {
+	return f.parent.getOrCreateInstrument(options.Name, options.Help, "", kindSum, nil).getOrCreatePoint(options.Tags)
+}
+
+func (f *factory) Gauge(options metrics.Options) metrics.Gauge {
+	return f.parent.getOrCreateInstrument(options.Name, options.Help, "", kindGauge, nil).getOrCreatePoint(options.Tags)
+}
+
+func (f *factory) Timer(options metrics.TimerOptions) metrics.Timer {
+	bounds := DefaultTimerBuckets
+	if len(options.Buckets) > 0 {
+		bounds = make([]float64, len(options.Buckets))
+		for i, b := range options.Buckets {
+			bounds[i] = b.Seconds()
+		}
+	}
+	i := f.parent.getOrCreateInstrument(options.Name, options.Help, "s", kindHistogram, sortedBounds(bounds))
+	return &timer{bounds: i.bounds, point: i.getOrCreatePoint(options.Tags)}
+}
+
+func (f *factory) Histogram(options metrics.HistogramOptions) metrics.Histogram {
+	bounds := DefaultBuckets
+	if len(options.Buckets) > 0 {
+		bounds = options.Buckets
+	}
+	i := f.parent.getOrCreateInstrument(options.Name, options.Help, "", kindHistogram, sortedBounds(bounds))
+	return &histogram{bounds: i.bounds, point: i.getOrCreatePoint(options.Tags)}
+}
+
+// sortedBounds returns a sorted copy of the bucket boundaries, without infinities,
+// since the last bucket is implicitly unbounded.
+func sortedBounds(buckets []float64) []float64 {
+	bounds := make([]float64, 0, len(buckets))
+	for _, b := range buckets {
+		if !math.IsInf(b, 0) && !math.IsNaN(b) {
+			bounds = append(bounds, b)
+		}
+	}
+	sort.Float64s(bounds)
+	return bounds
+}
+
+type timer struct {
+	bounds []float64
+	point  *point
+}
+
+func (t *timer) Record(d time.Duration) {
+	t.point.record(t.bounds, d.Seconds())
+}
+
+type histogram struct {
+	bounds []float64
+	point  *point
+}
+
+func (h *histogram) Record(v float64) {
+	h.point.record(h.bounds, v)
+}
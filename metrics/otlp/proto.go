@@ -0,0 +1,213 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The messages are encoded by hand following opentelemetry/proto/collector/metrics/v1
+// and opentelemetry/proto/metrics/v1, to avoid depending on the generated code.
+
+// Field numbers of the OTLP messages.
+const (
+	// ExportMetricsServiceRequest
+	requestResourceMetrics = 1
+
+	// ResourceMetrics
+	resourceMetricsResource = 1
+	resourceMetricsScope    = 2
+
+	// Resource
+	resourceAttributes = 1
+
+	// ScopeMetrics
+	scopeMetricsScope   = 1
+	scopeMetricsMetrics = 2
+
+	// InstrumentationScope
+	scopeName    = 1
+	scopeVersion = 2
+
+	// KeyValue and AnyValue
+	keyValueKey       = 1
+	keyValueValue     = 2
+	anyValueString    = 1
+	metricName        = 1
+	metricDescription = 2
+	metricUnit        = 3
+	metricGauge       = 5
+	metricSum         = 7
+	metricHistogram   = 9
+
+	// Gauge, Sum and Histogram
+	dataDataPoints  = 1
+	dataTemporality = 2
+	sumIsMonotonic  = 3
+
+	// NumberDataPoint
+	numberStartTime  = 2
+	numberTime       = 3
+	numberAsInt      = 6
+	numberAttributes = 7
+
+	// HistogramDataPoint
+	histogramStartTime      = 2
+	histogramTime           = 3
+	histogramCount          = 4
+	histogramSum            = 5
+	histogramBucketCounts   = 6
+	histogramExplicitBounds = 7
+	histogramAttributes     = 9
+	histogramMin            = 11
+	histogramMax            = 12
+)
+
+// exportRequest holds what is needed to encode an ExportMetricsServiceRequest.
+type exportRequest struct {
+	resource    []attribute
+	temporality Temporality
+	startTime   int64
+	time        int64
+	metrics     []metricData
+}
+
+func (r *exportRequest) encode() []byte {
+	var resource []byte
+	for _, a := range r.resource {
+		resource = appendMessage(resource, resourceAttributes, encodeKeyValue(a))
+	}
+	var scope []byte
+	scope = protowire.AppendTag(scope, scopeName, protowire.BytesType)
+	scope = protowire.AppendString(scope, instrumentationScope)
+
+	var scopeMetrics []byte
+	scopeMetrics = appendMessage(scopeMetrics, scopeMetricsScope, scope)
+	for _, m := range r.metrics {
+		scopeMetrics = appendMessage(scopeMetrics, scopeMetricsMetrics, r.encodeMetric(m))
+	}
+
+	var resourceMetrics []byte
+	resourceMetrics = appendMessage(resourceMetrics, resourceMetricsResource, resource)
+	resourceMetrics = appendMessage(resourceMetrics, resourceMetricsScope, scopeMetrics)
+
+	return appendMessage(nil, requestResourceMetrics, resourceMetrics)
+}
+
+func (r *exportRequest) encodeMetric(m metricData) []byte {
+	var b []byte
+	b = appendString(b, metricName, m.name)
+	b = appendString(b, metricDescription, m.description)
+	b = appendString(b, metricUnit, m.unit)
+
+	var data []byte
+	switch m.kind {
+	case kindSum, kindGauge:
+		for _, p := range m.points {
+			data = appendMessage(data, dataDataPoints, r.encodeNumberDataPoint(m.kind, p))
+		}
+		if m.kind == kindGauge {
+			return appendMessage(b, metricGauge, data)
+		}
+		data = protowire.AppendTag(data, dataTemporality, protowire.VarintType)
+		data = protowire.AppendVarint(data, uint64(r.temporality.proto()))
+		if m.monotonic {
+			data = protowire.AppendTag(data, sumIsMonotonic, protowire.VarintType)
+			data = protowire.AppendVarint(data, 1)
+		}
+		return appendMessage(b, metricSum, data)
+	default:
+		for _, p := range m.points {
+			data = appendMessage(data, dataDataPoints, r.encodeHistogramDataPoint(m.bounds, p))
+		}
+		data = protowire.AppendTag(data, dataTemporality, protowire.VarintType)
+		data = protowire.AppendVarint(data, uint64(r.temporality.proto()))
+		return appendMessage(b, metricHistogram, data)
+	}
+}
+
+func (r *exportRequest) encodeNumberDataPoint(k kind, p pointData) []byte {
+	var b []byte
+	for _, a := range p.attributes {
+		b = appendMessage(b, numberAttributes, encodeKeyValue(a))
+	}
+	if k == kindSum {
+		b = appendFixed64(b, numberStartTime, uint64(r.startTime))
+	}
+	b = appendFixed64(b, numberTime, uint64(r.time))
+	return appendFixed64(b, numberAsInt, uint64(p.value))
+}
+
+func (r *exportRequest) encodeHistogramDataPoint(bounds []float64, p pointData) []byte {
+	var b []byte
+	for _, a := range p.attributes {
+		b = appendMessage(b, histogramAttributes, encodeKeyValue(a))
+	}
+	b = appendFixed64(b, histogramStartTime, uint64(r.startTime))
+	b = appendFixed64(b, histogramTime, uint64(r.time))
+	b = appendFixed64(b, histogramCount, p.count)
+	b = appendFixed64(b, histogramSum, math.Float64bits(p.sum))
+
+	var counts []byte
+	for _, c := range p.bucketCounts {
+		counts = protowire.AppendFixed64(counts, c)
+	}
+	b = appendMessage(b, histogramBucketCounts, counts)
+	if len(bounds) > 0 {
+		var packed []byte
+		for _, bound := range bounds {
+			packed = protowire.AppendFixed64(packed, math.Float64bits(bound))
+		}
+		b = appendMessage(b, histogramExplicitBounds, packed)
+	}
+	if p.hasMinMax {
+		b = appendFixed64(b, histogramMin, math.Float64bits(p.min))
+		b = appendFixed64(b, histogramMax, math.Float64bits(p.max))
+	}
+	return b
+}
+
+func encodeKeyValue(a attribute) []byte {
+	var value []byte
+	value = protowire.AppendTag(value, anyValueString, protowire.BytesType)
+	value = protowire.AppendString(value, a.value)
+
+	var b []byte
+	b = protowire.AppendTag(b, keyValueKey, protowire.BytesType)
+	b = protowire.AppendString(b, a.key)
+	return appendMessage(b, keyValueValue, value)
+}
+
+// appendMessage appends an embedded message, or a packed repeated field.
+func appendMessage(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+// appendString appends a string field, omitted when empty as in proto3.
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendFixed64(b []byte, num protowire.Number, v uint64) []byte {
+	b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+	return protowire.AppendFixed64(b, v)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package process publishes statistics of the current process, read from the proc
+// filesystem, through any metrics.Factory.
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+const (
+	// DefaultInterval is how often the statistics are read.
+	DefaultInterval = 10 * time.Second
+
+	// DefaultProcFS is the mount point of the proc filesystem.
+	DefaultProcFS = "/proc"
+)
+
+type options struct {
+	interval     time.Duration
+	procFS       string
+	errorHandler func(error)
+}
+
+// Option is a function that sets some option for the Collector constructor.
+type Option func(*options)
+
+// WithInterval returns an option that sets how often the statistics are read.
+// If not used, we fallback to DefaultInterval. A non-positive interval disables
+// the periodic reads, Collect must be called instead.
+func WithInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.interval = interval
+	}
+}
+
+// WithProcFS returns an option that sets the mount point of the proc filesystem,
+// whose "self" entry is read. If not used, we fallback to DefaultProcFS.
+func WithProcFS(path string) Option {
+	return func(opts *options) {
+		opts.procFS = path
+	}
+}
+
+// WithErrorHandler returns an option that sets a function called with errors
+// of the periodic reads. If not used, such errors are dropped.
+func WithErrorHandler(handler func(error)) Option {
+	return func(opts *options) {
+		opts.errorHandler = handler
+	}
+}
+
+// Collector periodically reads the statistics of the current process from /proc/self
+// and publishes them in the namespace "process" of a metrics.Factory, with names
+// following the process collector of the Prometheus client, e.g. "process.open_fds":
+//
+//   - A Counter "cpu_seconds_total" with the user and system CPU time, in whole seconds.
+//   - Gauges "resident_memory_bytes" and "virtual_memory_bytes".
+//   - Gauges "open_fds" and "max_fds", the soft limit, which is not updated if unlimited.
+//   - Gauges "threads" and "start_time_seconds", since the epoch.
+//
+// If the proc filesystem is not available, e.g. on other systems than Linux,
+// the Collector does nothing and creates no metrics.
+type Collector struct {
+	procFS     string
+	pageSize   uint64
+	disabled   bool
+	errHandler func(error)
+
+	cpuSeconds  metrics.Counter
+	rss         metrics.Gauge
+	vsize       metrics.Gauge
+	openFDs     metrics.Gauge
+	maxFDs      metrics.Gauge
+	threads     metrics.Gauge
+	startTime   metrics.Gauge
+	lock        sync.Mutex
+	lastCPUSecs uint64
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// NewCollector creates a Collector publishing into factory, and starts reading
+// the statistics on the interval of WithInterval.
+func NewCollector(factory metrics.Factory, opts ...Option) *Collector {
+	options := &options{
+		interval:     DefaultInterval,
+		procFS:       DefaultProcFS,
+		errorHandler: func(error) {},
+	}
+	for _, o := range opts {
+		o(options)
+	}
+	c := &Collector{
+		procFS:     options.procFS,
+		pageSize:   uint64(os.Getpagesize()),
+		errHandler: options.errorHandler,
+		stop:       make(chan struct{}),
+	}
+	if _, err := os.Stat(selfPath(c.procFS, "stat")); err != nil {
+		c.disabled = true
+		return c
+	}
+
+	ns := factory.Namespace(metrics.NSOptions{Name: "process"})
+	c.cpuSeconds = ns.Counter(metrics.Options{Name: "cpu_seconds_total"})
+	c.rss = ns.Gauge(metrics.Options{Name: "resident_memory_bytes"})
+	c.vsize = ns.Gauge(metrics.Options{Name: "virtual_memory_bytes"})
+	c.openFDs = ns.Gauge(metrics.Options{Name: "open_fds"})
+	c.maxFDs = ns.Gauge(metrics.Options{Name: "max_fds"})
+	c.threads = ns.Gauge(metrics.Options{Name: "threads"})
+	c.startTime = ns.Gauge(metrics.Options{Name: "start_time_seconds"})
+
+	if options.interval > 0 {
+		c.wg.Add(1)
+		go c.loop(options.interval)
+	}
+	return c
+}
+
+func (c *Collector) loop(interval time.Duration) {
+	defer c.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := c.Collect(); err != nil {
+			c.errHandler(err)
+		}
+		select {
+		case <-ticker.C:
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the periodic reads.
+func (c *Collector) Stop() {
+	c.once.Do(func() {
+		close(c.stop)
+		c.wg.Wait()
+	})
+}
+
+// Collect reads the statistics and updates the metrics. It does nothing if the
+// proc filesystem is not available.
+func (c *Collector) Collect() error {
+	if c.disabled {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	stat, err := readStat(selfPath(c.procFS, "stat"))
+	if err != nil {
+		return err
+	}
+	if cpuSecs := (stat.utime + stat.stime) / userHZ; cpuSecs > c.lastCPUSecs {
+		c.cpuSeconds.Inc(int64(cpuSecs - c.lastCPUSecs))
+		c.lastCPUSecs = cpuSecs
+	}
+	c.rss.Update(int64(stat.rss * c.pageSize))
+	c.vsize.Update(int64(stat.vsize))
+	c.threads.Update(int64(stat.numThreads))
+
+	bootTime, err := readBootTime(filepath.Join(c.procFS, "stat"))
+	if err != nil {
+		return err
+	}
+	c.startTime.Update(bootTime + int64(stat.starttime/userHZ))
+
+	openFDs, err := countFDs(selfPath(c.procFS, "fd"))
+	if err != nil {
+		return err
+	}
+	c.openFDs.Update(openFDs)
+
+	maxFDs, err := readMaxFDs(selfPath(c.procFS, "limits"))
+	if err != nil {
+		return err
+	}
+	if maxFDs >= 0 {
+		c.maxFDs.Update(maxFDs)
+	}
+	return nil
+}
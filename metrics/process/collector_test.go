@@ -0,0 +1,144 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+)
+
+const (
+	fakeStat = "4242 (my (odd) cmd) S 1 4242 4242 0 -1 4194560 2000 0 0 0 " +
+		"1234 567 0 0 20 0 12 0 9000 123456789 2048 18446744073709551615 1 1 0 0 0 0 0 0 0 0 0 0 17 3 0 0 0 0 0\n"
+	fakeProcStat = "cpu  100 0 100 1000 0 0 0 0 0 0\nintr 1 2 3\nbtime 1700000000\nprocesses 42\n"
+	fakeLimits   = `Limit                     Soft Limit           Hard Limit           Units
+Max cpu time              unlimited            unlimited            seconds
+Max open files            1024                 1048576              files
+`
+)
+
+// newFakeProcFS creates a proc filesystem with the files read by the Collector,
+// and fds open file descriptors.
+func newFakeProcFS(t *testing.T, limits string, fds int) string {
+	dir, err := ioutil.TempDir("", "procfs")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "self", "fd"), 0755))
+	write := func(content string, elem ...string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(append([]string{dir}, elem...)...), []byte(content), 0644))
+	}
+	write(fakeStat, "self", "stat")
+	write(fakeProcStat, "stat")
+	write(limits, "self", "limits")
+	for i := 0; i < fds; i++ {
+		write("", "self", "fd", string(rune('0'+i)))
+	}
+	return dir
+}
+
+func TestCollector(t *testing.T) {
+	dir := newFakeProcFS(t, fakeLimits, 3)
+	defer os.RemoveAll(dir)
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	c := NewCollector(f, WithInterval(0), WithProcFS(dir))
+	require.NoError(t, c.Collect())
+
+	f.AssertCounterMetrics(t, metricstest.ExpectedMetric{Name: "process.cpu_seconds_total", Value: 18})
+	f.AssertGaugeMetrics(t,
+		metricstest.ExpectedMetric{Name: "process.resident_memory_bytes", Value: 2048 * os.Getpagesize()},
+		metricstest.ExpectedMetric{Name: "process.virtual_memory_bytes", Value: 123456789},
+		metricstest.ExpectedMetric{Name: "process.open_fds", Value: 3},
+		metricstest.ExpectedMetric{Name: "process.max_fds", Value: 1024},
+		metricstest.ExpectedMetric{Name: "process.threads", Value: 12},
+		metricstest.ExpectedMetric{Name: "process.start_time_seconds", Value: 1700000090},
+	)
+
+	// the CPU time is incremented by the whole seconds since the previous read
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "self", "stat"),
+		[]byte("4242 (cmd) S 1 4242 4242 0 -1 4194560 2000 0 0 0 1334 617 0 0 20 0 12 0 9000 1 2 3\n"), 0644))
+	require.NoError(t, c.Collect())
+	f.AssertCounterMetrics(t, metricstest.ExpectedMetric{Name: "process.cpu_seconds_total", Value: 19})
+}
+
+func TestCollectorUnlimitedFDs(t *testing.T) {
+	dir := newFakeProcFS(t, "Max open files            unlimited            unlimited            files\n", 0)
+	defer os.RemoveAll(dir)
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	c := NewCollector(f, WithInterval(0), WithProcFS(dir))
+	require.NoError(t, c.Collect())
+	f.AssertGaugeMetrics(t,
+		metricstest.ExpectedMetric{Name: "process.open_fds", Value: 0},
+		metricstest.ExpectedMetric{Name: "process.max_fds", Value: 0},
+	)
+}
+
+func TestCollectorErrors(t *testing.T) {
+	dir := newFakeProcFS(t, "", 0)
+	defer os.RemoveAll(dir)
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	errs := make(chan error, 1)
+	c := NewCollector(f, WithInterval(time.Hour), WithProcFS(dir), WithErrorHandler(func(err error) {
+		errs <- err
+	}))
+	err := <-errs
+	c.Stop()
+	c.Stop()
+	assert.Contains(t, err.Error(), `no "Max open files" in`)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "self", "stat"), []byte("4242 (cmd) S 1 2\n"), 0644))
+	assert.Contains(t, c.Collect().Error(), "cannot parse")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "self", "stat"), []byte("4242 (cmd) S 1 4242 4242 0 -1 4194560 2000 0 0 0 x 617 0 0 20 0 12 0 9000 1 2 3\n"), 0644))
+	assert.Contains(t, c.Collect().Error(), "cannot parse field 14")
+	require.NoError(t, os.Remove(filepath.Join(dir, "self", "stat")))
+	assert.True(t, os.IsNotExist(c.Collect()))
+}
+
+func TestCollectorWithoutProcFS(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	c := NewCollector(f, WithProcFS(filepath.Join(os.TempDir(), "no-such-procfs")), WithErrorHandler(func(err error) {
+		t.Error(err)
+	}))
+	assert.NoError(t, c.Collect())
+	c.Stop()
+	counters, gauges := f.Snapshot()
+	assert.Empty(t, counters)
+	assert.Empty(t, gauges)
+}
+
+func TestCollectorSelf(t *testing.T) {
+	if _, err := os.Stat("/proc/self/stat"); err != nil {
+		t.Skip("no proc filesystem")
+	}
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	c := NewCollector(f, WithInterval(0))
+	require.NoError(t, c.Collect())
+	_, gauges := f.Snapshot()
+	assert.True(t, gauges["process.resident_memory_bytes"] > 0)
+	assert.True(t, gauges["process.open_fds"] > 0)
+	assert.True(t, gauges["process.threads"] > 0)
+	assert.InDelta(t, time.Now().Unix(), gauges["process.start_time_seconds"], 3600)
+}
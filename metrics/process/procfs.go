@@ -0,0 +1,131 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// userHZ is the unit of the times of /proc/<pid>/stat, fixed on Linux whatever the kernel HZ.
+const userHZ = 100
+
+// procStat holds the fields of /proc/<pid>/stat we publish.
+type procStat struct {
+	utime      uint64 // in clock ticks
+	stime      uint64 // in clock ticks
+	numThreads uint64
+	starttime  uint64 // in clock ticks since boot
+	vsize      uint64 // in bytes
+	rss        uint64 // in pages
+}
+
+// readStat parses /proc/<pid>/stat, see proc(5).
+func readStat(path string) (procStat, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return procStat{}, err
+	}
+	// the command name, in parentheses, can contain spaces and parentheses
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return procStat{}, fmt.Errorf("process: cannot parse %s", path)
+	}
+	// fields[0] is the state, the 3rd field of the file
+	fields := strings.Fields(string(data[end+1:]))
+	if len(fields) < 22 {
+		return procStat{}, fmt.Errorf("process: cannot parse %s: %d fields", path, len(fields)+2)
+	}
+	field := func(n int) uint64 {
+		if err != nil {
+			return 0
+		}
+		var v uint64
+		if v, err = strconv.ParseUint(fields[n-3], 10, 64); err != nil {
+			err = fmt.Errorf("process: cannot parse field %d of %s: %v", n, path, err)
+		}
+		return v
+	}
+	stat := procStat{
+		utime:      field(14),
+		stime:      field(15),
+		numThreads: field(20),
+		starttime:  field(22),
+		vsize:      field(23),
+		rss:        field(24),
+	}
+	return stat, err
+}
+
+// readBootTime returns the "btime" of /proc/stat, the boot time in seconds since the epoch.
+func readBootTime(path string) (int64, error) {
+	return readLine(path, "btime", 1)
+}
+
+// readMaxFDs returns the soft limit of open files of /proc/<pid>/limits,
+// or -1 if unlimited.
+func readMaxFDs(path string) (int64, error) {
+	// Max open files            1024                 1048576              files
+	return readLine(path, "Max open files", 3)
+}
+
+// readLine parses the field of the first line of a file starting with prefix.
+func readLine(path, prefix string, field int) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) <= field {
+			break
+		}
+		if fields[field] == "unlimited" {
+			return -1, nil
+		}
+		return strconv.ParseInt(fields[field], 10, 64)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("process: no %q in %s", prefix, path)
+}
+
+// countFDs returns the number of entries of /proc/<pid>/fd.
+func countFDs(path string) (int64, error) {
+	d, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer d.Close()
+	names, err := d.Readdirnames(-1)
+	return int64(len(names)), err
+}
+
+func selfPath(procFS string, elem ...string) string {
+	return filepath.Join(append([]string{procFS, "self"}, elem...)...)
+}
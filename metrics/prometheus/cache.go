@@ -28,6 +28,7 @@ type vectorCache struct {
 	cVecs      map[string]*prometheus.CounterVec
 	gVecs      map[string]*prometheus.GaugeVec
 	hVecs      map[string]*prometheus.HistogramVec
+	sVecs      map[string]*prometheus.SummaryVec
 }
 
 func newVectorCache(registerer prometheus.Registerer) *vectorCache {
@@ -36,6 +37,7 @@ func newVectorCache(registerer prometheus.Registerer) *vectorCache {
 		cVecs:      make(map[string]*prometheus.CounterVec),
 		gVecs:      make(map[string]*prometheus.GaugeVec),
 		hVecs:      make(map[string]*prometheus.HistogramVec),
+		sVecs:      make(map[string]*prometheus.SummaryVec),
 	}
 }
 
@@ -81,6 +83,20 @@ func (c *vectorCache) getOrMakeHistogramVec(opts prometheus.HistogramOpts, label
 	return hv
 }
 
+func (c *vectorCache) getOrMakeSummaryVec(opts prometheus.SummaryOpts, labelNames []string) *prometheus.SummaryVec {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	cacheKey := c.getCacheKey(opts.Name, labelNames)
+	sv, svExists := c.sVecs[cacheKey]
+	if !svExists {
+		sv = prometheus.NewSummaryVec(opts, labelNames)
+		c.registerer.MustRegister(sv)
+		c.sVecs[cacheKey] = sv
+	}
+	return sv
+}
+
 func (c *vectorCache) getCacheKey(name string, labels []string) string {
 	return strings.Join(append([]string{name}, labels...), "||")
 }
@@ -32,12 +32,113 @@ type Factory struct {
 	buckets    []float64
 	normalizer *strings.Replacer
 	separator  Separator
+	native     *nativeHistograms
+	timers     *timerTypes
 }
 
 type options struct {
 	registerer prometheus.Registerer
 	buckets    []float64
 	separator  Separator
+	native     *nativeHistograms
+	timers     *timerTypes
+}
+
+// NativeHistogramOptions defines how Timers and Histograms are exported as
+// Prometheus native (sparse) histograms. See prometheus.HistogramOpts for
+// the detailed semantics of each field.
+type NativeHistogramOptions struct {
+	// BucketFactor is the upper bound for the growth factor between two
+	// consecutive native buckets, e.g. 1.1. Native histograms are only
+	// enabled when BucketFactor is greater than one.
+	BucketFactor float64
+
+	// MaxBucketNumber limits the number of populated native buckets.
+	// Zero means no limit.
+	MaxBucketNumber uint32
+
+	// ZeroThreshold is the width of the zero bucket. Zero means the
+	// Prometheus default, use prometheus.NativeHistogramZeroThresholdZero
+	// for a zero bucket that only accepts observations of exactly zero.
+	ZeroThreshold float64
+
+	// MinResetDuration and MaxZeroThreshold control how the histogram is
+	// reduced once MaxBucketNumber is exceeded.
+	MinResetDuration time.Duration
+	MaxZeroThreshold float64
+
+	// ClassicBuckets keeps exporting the classic buckets next to the native
+	// ones, which is useful while migrating dashboards and alerts.
+	ClassicBuckets bool
+}
+
+func (o NativeHistogramOptions) enabled() bool {
+	return o.BucketFactor > 1
+}
+
+// nativeHistograms holds the native histogram settings of a Factory,
+// the defaults and the per-metric overrides keyed by the metric name.
+type nativeHistograms struct {
+	defaults  NativeHistogramOptions
+	overrides map[string]NativeHistogramOptions
+}
+
+func (n *nativeHistograms) forMetric(name string) NativeHistogramOptions {
+	if n == nil {
+		return NativeHistogramOptions{}
+	}
+	if o, ok := n.overrides[name]; ok {
+		return o
+	}
+	return n.defaults
+}
+
+// TimerType defines which Prometheus metric type backs a Timer.
+type TimerType int
+
+const (
+	// TimerTypeHistogram exports Timers as histograms
+	TimerTypeHistogram TimerType = iota
+
+	// TimerTypeSummary exports Timers as summaries with fixed objectives
+	TimerTypeSummary
+)
+
+// defaultObjectives are used by summary Timers when no objectives are given.
+var defaultObjectives = map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
+
+type timerType struct {
+	timerType  TimerType
+	objectives map[float64]float64
+	maxAge     time.Duration
+}
+
+// timerTypes holds the Timer types of a Factory, the default and
+// the per-metric overrides keyed by the metric name.
+type timerTypes struct {
+	defaults  timerType
+	overrides map[string]timerType
+}
+
+func (t *timerTypes) forMetric(name string) timerType {
+	if t == nil {
+		return timerType{}
+	}
+	if o, ok := t.overrides[name]; ok {
+		return o
+	}
+	return t.defaults
+}
+
+func newTimerType(tt TimerType, objectives map[float64]float64, maxAge time.Duration) timerType {
+	if tt == TimerTypeSummary && len(objectives) == 0 {
+		objectives = defaultObjectives
+	}
+	return timerType{
+		timerType:  tt,
+		objectives: objectives,
+		maxAge:     maxAge,
+	}
 }
 
 // Separator represents the namespace separator to use
@@ -78,6 +179,64 @@ func WithSeparator(separator Separator) Option {
 	}
 }
 
+// WithNativeHistograms returns an option that enables native histograms
+// for all Timers and Histograms created by the Factory.
+// If not used, only classic buckets are exported.
+func WithNativeHistograms(native NativeHistogramOptions) Option {
+	return func(opts *options) {
+		opts.nativeHistograms().defaults = native
+	}
+}
+
+// WithMetricNativeHistograms returns an option that overrides the native histogram
+// settings for a single metric, identified by its fully qualified Prometheus name,
+// e.g. "jaeger_query_latency". Passing zero NativeHistogramOptions disables
+// native histograms for that metric.
+func WithMetricNativeHistograms(name string, native NativeHistogramOptions) Option {
+	return func(opts *options) {
+		opts.nativeHistograms().overrides[name] = native
+	}
+}
+
+// WithTimerType returns an option that sets the metric type backing all Timers.
+// For TimerTypeSummary, objectives maps quantiles to their allowed absolute error
+// and maxAge defines the sliding time window of the summary; when not set,
+// objectives for the 50th, 90th and 99th percentiles and the Prometheus
+// default window are used. Both arguments are ignored for TimerTypeHistogram.
+// If not used, Timers are exported as histograms.
+func WithTimerType(tt TimerType, objectives map[float64]float64, maxAge time.Duration) Option {
+	return func(opts *options) {
+		opts.timerTypes().defaults = newTimerType(tt, objectives, maxAge)
+	}
+}
+
+// WithMetricTimerType returns an option that overrides the Timer type for a single
+// metric, identified by its fully qualified Prometheus name, e.g. "jaeger_query_latency".
+// The arguments have the same meaning as in WithTimerType.
+func WithMetricTimerType(name string, tt TimerType, objectives map[float64]float64, maxAge time.Duration) Option {
+	return func(opts *options) {
+		opts.timerTypes().overrides[name] = newTimerType(tt, objectives, maxAge)
+	}
+}
+
+func (o *options) timerTypes() *timerTypes {
+	if o.timers == nil {
+		o.timers = &timerTypes{
+			overrides: make(map[string]timerType),
+		}
+	}
+	return o.timers
+}
+
+func (o *options) nativeHistograms() *nativeHistograms {
+	if o.native == nil {
+		o.native = &nativeHistograms{
+			overrides: make(map[string]NativeHistogramOptions),
+		}
+	}
+	return o.native
+}
+
 func applyOptions(opts []Option) *options {
 	options := new(options)
 	for _, o := range opts {
@@ -108,6 +267,8 @@ func New(opts ...Option) *Factory {
 			buckets:    options.buckets,
 			normalizer: strings.NewReplacer(".", "_", "-", "_"),
 			separator:  options.separator,
+			native:     options.native,
+			timers:     options.timers,
 		},
 		"",  // scope
 		nil) // tags
@@ -119,6 +280,8 @@ func newFactory(parent *Factory, scope string, tags map[string]string) *Factory
 		buckets:    parent.buckets,
 		normalizer: parent.normalizer,
 		separator:  parent.separator,
+		native:     parent.native,
+		timers:     parent.timers,
 		scope:      scope,
 		tags:       tags,
 	}
@@ -169,18 +332,52 @@ func (f *Factory) Timer(options metrics.TimerOptions) metrics.Timer {
 		help = options.Name
 	}
 	name := f.subScope(options.Name)
-	buckets := f.selectBuckets(asFloatBuckets(options.Buckets))
 	tags := f.mergeTags(options.Tags)
 	labelNames := f.tagNames(tags)
+	if tt := f.timers.forMetric(name); tt.timerType == TimerTypeSummary {
+		opts := prometheus.SummaryOpts{
+			Name:       name,
+			Help:       help,
+			Objectives: tt.objectives,
+			MaxAge:     tt.maxAge,
+		}
+		sv := f.cache.getOrMakeSummaryVec(opts, labelNames)
+		return &timer{
+			observer: sv.WithLabelValues(f.tagsAsLabelValues(labelNames, tags)...),
+		}
+	}
+	buckets := f.selectBuckets(asFloatBuckets(options.Buckets))
+	opts := f.histogramOpts(name, help, buckets)
+	hv := f.cache.getOrMakeHistogramVec(opts, labelNames)
+	return &timer{
+		observer: hv.WithLabelValues(f.tagsAsLabelValues(labelNames, tags)...),
+	}
+}
+
+// histogramOpts builds the options for a histogram vector, enabling native
+// histograms when they are configured for the given metric name.
+func (f *Factory) histogramOpts(name, help string, buckets []float64) prometheus.HistogramOpts {
 	opts := prometheus.HistogramOpts{
 		Name:    name,
 		Help:    help,
 		Buckets: buckets,
 	}
-	hv := f.cache.getOrMakeHistogramVec(opts, labelNames)
-	return &timer{
-		histogram: hv.WithLabelValues(f.tagsAsLabelValues(labelNames, tags)...),
+	native := f.native.forMetric(name)
+	if !native.enabled() {
+		return opts
+	}
+	opts.NativeHistogramBucketFactor = native.BucketFactor
+	opts.NativeHistogramMaxBucketNumber = native.MaxBucketNumber
+	opts.NativeHistogramZeroThreshold = native.ZeroThreshold
+	opts.NativeHistogramMinResetDuration = native.MinResetDuration
+	opts.NativeHistogramMaxZeroThreshold = native.MaxZeroThreshold
+	if !native.ClassicBuckets {
+		opts.Buckets = nil
+	} else if len(opts.Buckets) == 0 {
+		// with native histograms enabled Prometheus no longer falls back to DefBuckets
+		opts.Buckets = prometheus.DefBuckets
 	}
+	return opts
 }
 
 func asFloatBuckets(buckets []time.Duration) []float64 {
@@ -201,11 +398,7 @@ func (f *Factory) Histogram(options metrics.HistogramOptions) metrics.Histogram
 	buckets := f.selectBuckets(options.Buckets)
 	tags := f.mergeTags(options.Tags)
 	labelNames := f.tagNames(tags)
-	opts := prometheus.HistogramOpts{
-		Name:    name,
-		Help:    help,
-		Buckets: buckets,
-	}
+	opts := f.histogramOpts(name, help, buckets)
 	hv := f.cache.getOrMakeHistogramVec(opts, labelNames)
 	return &histogram{
 		histogram: hv.WithLabelValues(f.tagsAsLabelValues(labelNames, tags)...),
@@ -238,11 +431,11 @@ type observer interface {
 }
 
 type timer struct {
-	histogram observer
+	observer observer
 }
 
 func (t *timer) Record(v time.Duration) {
-	t.histogram.Observe(float64(v.Nanoseconds()) / float64(time.Second/time.Nanosecond))
+	t.observer.Observe(float64(v.Nanoseconds()) / float64(time.Second/time.Nanosecond))
 }
 
 type histogram struct {
@@ -165,81 +165,155 @@ func TestGaugeDefaultHelp(t *testing.T) {
 	assert.EqualValues(t, "rodriguez", snapshot[0].GetHelp())
 }
 
+var timerTypes = []struct {
+	name    string
+	options []Option
+}{
+	{name: "histogram"},
+	{name: "summary", options: []Option{WithTimerType(TimerTypeSummary, nil, 0)}},
+}
+
 func TestTimer(t *testing.T) {
+	for _, tt := range timerTypes {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := prometheus.NewPedanticRegistry()
+			f1 := New(append(tt.options, WithRegisterer(registry))...)
+			f2 := f1.Namespace(metrics.NSOptions{
+				Name: "bender",
+				Tags: map[string]string{"a": "b"},
+			})
+			f3 := f2.Namespace(metrics.NSOptions{
+				Tags: map[string]string{"a": "b"},
+			}) // essentially same as f2
+			t1 := f2.Timer(metrics.TimerOptions{
+				Name: "rodriguez",
+				Tags: map[string]string{"x": "y"},
+				Help: "Help message",
+			})
+			t2 := f2.Timer(metrics.TimerOptions{
+				Name: "rodriguez",
+				Tags: map[string]string{"x": "z"},
+				Help: "Help message",
+			})
+			t3 := f3.Timer(metrics.TimerOptions{
+				Name: "rodriguez",
+				Tags: map[string]string{"x": "z"},
+				Help: "Help message",
+			}) // same as t2, but from f3
+			t1.Record(1 * time.Second)
+			t1.Record(2 * time.Second)
+			t2.Record(3 * time.Second)
+			t3.Record(4 * time.Second)
+
+			snapshot, err := registry.Gather()
+			require.NoError(t, err)
+
+			assert.EqualValues(t, "Help message", snapshot[0].GetHelp())
+
+			m1 := findMetric(t, snapshot, "bender_rodriguez", map[string]string{"a": "b", "x": "y"})
+			assertTimer(t, m1, 2, 3)
+			for _, bucket := range m1.GetHistogram().GetBucket() {
+				if bucket.GetUpperBound() < 1 {
+					assert.EqualValues(t, 0, bucket.GetCumulativeCount())
+				} else if bucket.GetUpperBound() < 2 {
+					assert.EqualValues(t, 1, bucket.GetCumulativeCount())
+				} else {
+					assert.EqualValues(t, 2, bucket.GetCumulativeCount())
+				}
+			}
+
+			m2 := findMetric(t, snapshot, "bender_rodriguez", map[string]string{"a": "b", "x": "z"})
+			assertTimer(t, m2, 2, 7)
+			for _, bucket := range m2.GetHistogram().GetBucket() {
+				if bucket.GetUpperBound() < 3 {
+					assert.EqualValues(t, 0, bucket.GetCumulativeCount())
+				} else if bucket.GetUpperBound() < 4 {
+					assert.EqualValues(t, 1, bucket.GetCumulativeCount())
+				} else {
+					assert.EqualValues(t, 2, bucket.GetCumulativeCount())
+				}
+			}
+			for _, quantile := range m2.GetSummary().GetQuantile() {
+				if quantile.GetQuantile() < 0.9 {
+					assert.EqualValues(t, 3, quantile.GetValue())
+				} else {
+					assert.EqualValues(t, 4, quantile.GetValue())
+				}
+			}
+		})
+	}
+}
+
+func TestTimerDefaultHelp(t *testing.T) {
+	for _, tt := range timerTypes {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := prometheus.NewPedanticRegistry()
+			f1 := New(append(tt.options, WithRegisterer(registry))...)
+			t1 := f1.Timer(metrics.TimerOptions{
+				Name: "rodriguez",
+				Tags: map[string]string{"x": "y"},
+			})
+			t1.Record(1 * time.Second)
+
+			snapshot, err := registry.Gather()
+			require.NoError(t, err)
+
+			assert.EqualValues(t, "rodriguez", snapshot[0].GetHelp())
+		})
+	}
+}
+
+func TestSummaryTimer(t *testing.T) {
 	registry := prometheus.NewPedanticRegistry()
-	f1 := New(WithRegisterer(registry))
-	f2 := f1.Namespace(metrics.NSOptions{
-		Name: "bender",
-		Tags: map[string]string{"a": "b"},
-	})
-	f3 := f2.Namespace(metrics.NSOptions{
-		Tags: map[string]string{"a": "b"},
-	}) // essentially same as f2
-	t1 := f2.Timer(metrics.TimerOptions{
-		Name: "rodriguez",
-		Tags: map[string]string{"x": "y"},
-		Help: "Help message",
-	})
-	t2 := f2.Timer(metrics.TimerOptions{
-		Name: "rodriguez",
-		Tags: map[string]string{"x": "z"},
-		Help: "Help message",
+	f1 := New(
+		WithRegisterer(registry),
+		WithTimerType(TimerTypeSummary, map[float64]float64{0.5: 0.05, 0.99: 0.001}, time.Minute),
+	)
+	// buckets are ignored by summaries
+	t1 := f1.Timer(metrics.TimerOptions{
+		Name:    "bender.bending-rodriguez",
+		Tags:    map[string]string{"x": "y"},
+		Buckets: []time.Duration{time.Second},
 	})
-	t3 := f3.Timer(metrics.TimerOptions{
-		Name: "rodriguez",
-		Tags: map[string]string{"x": "z"},
-		Help: "Help message",
-	}) // same as t2, but from f3
 	t1.Record(1 * time.Second)
 	t1.Record(2 * time.Second)
-	t2.Record(3 * time.Second)
-	t3.Record(4 * time.Second)
 
 	snapshot, err := registry.Gather()
 	require.NoError(t, err)
 
-	assert.EqualValues(t, "Help message", snapshot[0].GetHelp())
-
-	m1 := findMetric(t, snapshot, "bender_rodriguez", map[string]string{"a": "b", "x": "y"})
-	assert.EqualValues(t, 2, m1.GetHistogram().GetSampleCount(), "%+v", m1)
-	assert.EqualValues(t, 3, m1.GetHistogram().GetSampleSum(), "%+v", m1)
-	for _, bucket := range m1.GetHistogram().GetBucket() {
-		if bucket.GetUpperBound() < 1 {
-			assert.EqualValues(t, 0, bucket.GetCumulativeCount())
-		} else if bucket.GetUpperBound() < 2 {
-			assert.EqualValues(t, 1, bucket.GetCumulativeCount())
-		} else {
-			assert.EqualValues(t, 2, bucket.GetCumulativeCount())
-		}
-	}
-
-	m2 := findMetric(t, snapshot, "bender_rodriguez", map[string]string{"a": "b", "x": "z"})
-	assert.EqualValues(t, 2, m2.GetHistogram().GetSampleCount(), "%+v", m2)
-	assert.EqualValues(t, 7, m2.GetHistogram().GetSampleSum(), "%+v", m2)
-	for _, bucket := range m2.GetHistogram().GetBucket() {
-		if bucket.GetUpperBound() < 3 {
-			assert.EqualValues(t, 0, bucket.GetCumulativeCount())
-		} else if bucket.GetUpperBound() < 4 {
-			assert.EqualValues(t, 1, bucket.GetCumulativeCount())
-		} else {
-			assert.EqualValues(t, 2, bucket.GetCumulativeCount())
-		}
-	}
+	m1 := findMetric(t, snapshot, "bender_bending_rodriguez", map[string]string{"x": "y"})
+	require.NotNil(t, m1.GetSummary(), "%+v", m1)
+	assertTimer(t, m1, 2, 3)
+	quantiles := m1.GetSummary().GetQuantile()
+	require.Len(t, quantiles, 2)
+	assert.EqualValues(t, 0.5, quantiles[0].GetQuantile())
+	assert.EqualValues(t, 1, quantiles[0].GetValue())
+	assert.EqualValues(t, 0.99, quantiles[1].GetQuantile())
+	assert.EqualValues(t, 2, quantiles[1].GetValue())
 }
 
-func TestTimerDefaultHelp(t *testing.T) {
+func TestMetricTimerType(t *testing.T) {
 	registry := prometheus.NewPedanticRegistry()
-	f1 := New(WithRegisterer(registry))
-	t1 := f1.Timer(metrics.TimerOptions{
-		Name: "rodriguez",
-		Tags: map[string]string{"x": "y"},
-	})
-	t1.Record(1 * time.Second)
+	f1 := New(
+		WithRegisterer(registry),
+		WithTimerType(TimerTypeSummary, nil, 0),
+		WithMetricTimerType("bender_histogram", TimerTypeHistogram, nil, 0),
+	)
+	f2 := f1.Namespace(metrics.NSOptions{Name: "bender"})
+	f2.Timer(metrics.TimerOptions{Name: "summary"}).Record(time.Second)
+	f2.Timer(metrics.TimerOptions{Name: "histogram"}).Record(time.Second)
 
 	snapshot, err := registry.Gather()
 	require.NoError(t, err)
 
-	assert.EqualValues(t, "rodriguez", snapshot[0].GetHelp())
+	m1 := findMetric(t, snapshot, "bender_summary", map[string]string{})
+	assert.NotNil(t, m1.GetSummary(), "%+v", m1)
+	assert.Len(t, m1.GetSummary().GetQuantile(), 3)
+	assert.Nil(t, m1.GetHistogram(), "%+v", m1)
+
+	m2 := findMetric(t, snapshot, "bender_histogram", map[string]string{})
+	assert.NotNil(t, m2.GetHistogram(), "%+v", m2)
+	assert.Nil(t, m2.GetSummary(), "%+v", m2)
 }
 
 func TestTimerCustomBuckets(t *testing.T) {
@@ -403,6 +477,111 @@ func TestHistogramDefaultBuckets(t *testing.T) {
 	assert.Len(t, m1.GetHistogram().GetBucket(), 1)
 }
 
+func TestNativeHistograms(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	f1 := New(WithRegisterer(registry), WithNativeHistograms(NativeHistogramOptions{
+		BucketFactor:    1.1,
+		MaxBucketNumber: 100,
+		ZeroThreshold:   0.001,
+	}))
+	t1 := f1.Timer(metrics.TimerOptions{
+		Name: "bender.timer",
+		Tags: map[string]string{"x": "y"},
+	})
+	h1 := f1.Histogram(metrics.HistogramOptions{
+		Name:    "bender.histogram",
+		Tags:    map[string]string{"x": "y"},
+		Buckets: []float64{1.5},
+	})
+	t1.Record(1 * time.Second)
+	t1.Record(2 * time.Second)
+	h1.Record(1)
+	h1.Record(2)
+
+	snapshot, err := registry.Gather()
+	require.NoError(t, err)
+
+	for _, name := range []string{"bender_timer", "bender_histogram"} {
+		m := findMetric(t, snapshot, name, map[string]string{"x": "y"})
+		assert.EqualValues(t, 2, m.GetHistogram().GetSampleCount(), "%+v", m)
+		assert.EqualValues(t, 3, m.GetHistogram().GetSampleSum(), "%+v", m)
+		assert.EqualValues(t, 3, m.GetHistogram().GetSchema(), "%+v", m)
+		assert.EqualValues(t, 0.001, m.GetHistogram().GetZeroThreshold(), "%+v", m)
+		assert.NotEmpty(t, m.GetHistogram().GetPositiveSpan(), "%+v", m)
+		assert.Empty(t, m.GetHistogram().GetBucket(), "classic buckets are not exported")
+	}
+}
+
+func TestNativeHistogramsWithClassicBuckets(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	f1 := New(WithRegisterer(registry), WithNativeHistograms(NativeHistogramOptions{
+		BucketFactor:   1.1,
+		ClassicBuckets: true,
+	}))
+	t1 := f1.Timer(metrics.TimerOptions{
+		Name: "bender.timer",
+		Tags: map[string]string{"x": "y"},
+	})
+	h1 := f1.Histogram(metrics.HistogramOptions{
+		Name:    "bender.histogram",
+		Tags:    map[string]string{"x": "y"},
+		Buckets: []float64{1.5},
+	})
+	t1.Record(1 * time.Second)
+	h1.Record(1)
+
+	snapshot, err := registry.Gather()
+	require.NoError(t, err)
+
+	m1 := findMetric(t, snapshot, "bender_timer", map[string]string{"x": "y"})
+	assert.EqualValues(t, 3, m1.GetHistogram().GetSchema(), "%+v", m1)
+	assert.Len(t, m1.GetHistogram().GetBucket(), len(prometheus.DefBuckets))
+
+	m2 := findMetric(t, snapshot, "bender_histogram", map[string]string{"x": "y"})
+	assert.EqualValues(t, 3, m2.GetHistogram().GetSchema(), "%+v", m2)
+	assert.Len(t, m2.GetHistogram().GetBucket(), 1)
+}
+
+func TestMetricNativeHistograms(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	f1 := New(
+		WithRegisterer(registry),
+		WithBuckets([]float64{1.5}),
+		WithNativeHistograms(NativeHistogramOptions{BucketFactor: 1.1}),
+		WithMetricNativeHistograms("bender_classic", NativeHistogramOptions{}),
+		WithMetricNativeHistograms("bender_coarse", NativeHistogramOptions{BucketFactor: 2}),
+	)
+	f2 := f1.Namespace(metrics.NSOptions{Name: "bender"})
+	f2.Timer(metrics.TimerOptions{Name: "native"}).Record(time.Second)
+	f2.Timer(metrics.TimerOptions{Name: "classic"}).Record(time.Second)
+	f2.Histogram(metrics.HistogramOptions{Name: "coarse"}).Record(1)
+
+	snapshot, err := registry.Gather()
+	require.NoError(t, err)
+
+	m1 := findMetric(t, snapshot, "bender_native", map[string]string{})
+	assert.EqualValues(t, 3, m1.GetHistogram().GetSchema(), "%+v", m1)
+	assert.Empty(t, m1.GetHistogram().GetBucket())
+
+	m2 := findMetric(t, snapshot, "bender_classic", map[string]string{})
+	assert.Nil(t, m2.GetHistogram().Schema, "%+v", m2)
+	assert.Len(t, m2.GetHistogram().GetBucket(), 1)
+
+	m3 := findMetric(t, snapshot, "bender_coarse", map[string]string{})
+	assert.EqualValues(t, 0, m3.GetHistogram().GetSchema(), "%+v", m3)
+	assert.NotNil(t, m3.GetHistogram().Schema, "%+v", m3)
+}
+
+func assertTimer(t *testing.T, m *promModel.Metric, count uint64, sum float64) {
+	if s := m.GetSummary(); s != nil {
+		assert.EqualValues(t, count, s.GetSampleCount(), "%+v", m)
+		assert.EqualValues(t, sum, s.GetSampleSum(), "%+v", m)
+		return
+	}
+	assert.EqualValues(t, count, m.GetHistogram().GetSampleCount(), "%+v", m)
+	assert.EqualValues(t, sum, m.GetHistogram().GetSampleSum(), "%+v", m)
+}
+
 func findMetric(t *testing.T, snapshot []*promModel.MetricFamily, name string, tags map[string]string) *promModel.Metric {
 	for _, mf := range snapshot {
 		if mf.GetName() != name {
@@ -0,0 +1,265 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// PushMethod defines how pushed metrics are merged with the ones already
+// stored in the Pushgateway for the same grouping key.
+type PushMethod int
+
+const (
+	// PushMethodReplace replaces all metrics with the same grouping key (HTTP PUT)
+	PushMethodReplace PushMethod = iota
+
+	// PushMethodAdd only replaces metrics with the same name and grouping key (HTTP POST)
+	PushMethodAdd
+)
+
+const (
+	defaultPushInterval   = 15 * time.Second
+	defaultPushRetries    = 3
+	defaultPushBackoff    = 100 * time.Millisecond
+	defaultPushMaxBackoff = 5 * time.Second
+	defaultPushTimeout    = 10 * time.Second
+)
+
+// errNotGatherer is returned when the Factory's registerer cannot be gathered from.
+var errNotGatherer = errors.New("the registerer of the factory does not implement prometheus.Gatherer")
+
+// Pusher pushes the metrics registered by a Factory to a Prometheus Pushgateway,
+// periodically and when closed. It is meant for batch jobs and short-lived
+// processes that finish before they can be scraped.
+type Pusher struct {
+	pusher  *push.Pusher
+	options pusherOptions
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+type pusherOptions struct {
+	interval     time.Duration
+	grouping     map[string]string
+	method       PushMethod
+	retries      int
+	backoff      time.Duration
+	maxBackoff   time.Duration
+	timeout      time.Duration
+	client       push.HTTPDoer
+	errorHandler func(error)
+}
+
+// PusherOption is a function that sets some option for the Pusher constructor.
+type PusherOption func(*pusherOptions)
+
+// WithPushInterval returns an option that sets how often metrics are pushed.
+// A non-positive interval disables periodic pushes, in which case metrics
+// are only pushed on Push and Close. If not used, we fallback to 15 seconds.
+func WithPushInterval(interval time.Duration) PusherOption {
+	return func(opts *pusherOptions) {
+		opts.interval = interval
+	}
+}
+
+// WithPushGrouping returns an option that adds a grouping label to the
+// grouping key, next to the job name.
+func WithPushGrouping(name, value string) PusherOption {
+	return func(opts *pusherOptions) {
+		opts.grouping[name] = value
+	}
+}
+
+// WithPushMethod returns an option that sets how pushed metrics are merged
+// in the Pushgateway. If not used, we fallback to PushMethodReplace.
+func WithPushMethod(method PushMethod) PusherOption {
+	return func(opts *pusherOptions) {
+		opts.method = method
+	}
+}
+
+// WithPushRetries returns an option that sets how many times a failed push is
+// retried, and the backoff between attempts, which doubles after each attempt
+// up to maxBackoff. If not used, a push is retried 3 times starting with
+// a 100ms backoff of up to 5 seconds.
+func WithPushRetries(retries int, backoff, maxBackoff time.Duration) PusherOption {
+	return func(opts *pusherOptions) {
+		opts.retries = retries
+		opts.backoff = backoff
+		opts.maxBackoff = maxBackoff
+	}
+}
+
+// WithPushTimeout returns an option that sets how long a single push attempt
+// may take. A non-positive timeout disables it. If not used, we fallback to
+// 10 seconds.
+func WithPushTimeout(timeout time.Duration) PusherOption {
+	return func(opts *pusherOptions) {
+		opts.timeout = timeout
+	}
+}
+
+// WithPushClient returns an option that sets the HTTP client used for pushing.
+// If not used, we fallback to http.DefaultClient.
+func WithPushClient(client push.HTTPDoer) PusherOption {
+	return func(opts *pusherOptions) {
+		opts.client = client
+	}
+}
+
+// WithPushErrorHandler returns an option that sets a function called with the
+// error of each periodic push that failed after all retries.
+// If not used, such errors are dropped.
+func WithPushErrorHandler(handler func(error)) PusherOption {
+	return func(opts *pusherOptions) {
+		opts.errorHandler = handler
+	}
+}
+
+func applyPusherOptions(opts []PusherOption) pusherOptions {
+	options := pusherOptions{
+		interval:     defaultPushInterval,
+		grouping:     make(map[string]string),
+		retries:      defaultPushRetries,
+		backoff:      defaultPushBackoff,
+		maxBackoff:   defaultPushMaxBackoff,
+		timeout:      defaultPushTimeout,
+		errorHandler: func(error) {},
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+	return options
+}
+
+// NewPusher creates a Pusher that pushes the metrics registered by the factory
+// to the Pushgateway at url, under the given job name. The factory must have been
+// created with a registerer that is also a prometheus.Gatherer, which is the case
+// for *prometheus.Registry and prometheus.DefaultRegisterer.
+func NewPusher(factory *Factory, url, job string, opts ...PusherOption) (*Pusher, error) {
+	gatherer, ok := factory.cache.registerer.(prometheus.Gatherer)
+	if !ok {
+		return nil, errNotGatherer
+	}
+	options := applyPusherOptions(opts)
+	pusher := push.New(url, job).Gatherer(gatherer)
+	for name, value := range options.grouping {
+		pusher = pusher.Grouping(name, value)
+	}
+	if options.client != nil {
+		pusher = pusher.Client(options.client)
+	}
+	p := &Pusher{
+		pusher:  pusher,
+		options: options,
+		stop:    make(chan struct{}),
+	}
+	if options.interval > 0 {
+		p.wg.Add(1)
+		go p.runLoop()
+	}
+	return p, nil
+}
+
+func (p *Pusher) runLoop() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.options.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.pushWithRetries(p.stop); err != nil {
+				p.options.errorHandler(err)
+			}
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// Push pushes the current metrics, retrying on failure.
+func (p *Pusher) Push() error {
+	return p.pushWithRetries(nil)
+}
+
+// Close stops the periodic pushes and pushes the final metrics.
+func (p *Pusher) Close() error {
+	var err error
+	p.once.Do(func() {
+		close(p.stop)
+		p.wg.Wait()
+		err = p.Push()
+	})
+	return err
+}
+
+// pushWithRetries pushes the metrics, retrying with exponential backoff.
+// Retrying and the push in flight are abandoned when the cancel channel is closed.
+func (p *Pusher) pushWithRetries(cancel <-chan struct{}) error {
+	backoff := p.options.backoff
+	for attempt := 0; ; attempt++ {
+		err := p.push(cancel)
+		if err == nil || attempt >= p.options.retries {
+			return err
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-cancel:
+			timer.Stop()
+			return err
+		}
+		backoff *= 2
+		if backoff > p.options.maxBackoff {
+			backoff = p.options.maxBackoff
+		}
+	}
+}
+
+// push makes a single push attempt, bounded by the push timeout and
+// cancelled when the cancel channel is closed.
+func (p *Pusher) push(cancel <-chan struct{}) error {
+	var ctx context.Context
+	var cancelCtx context.CancelFunc
+	if p.options.timeout > 0 {
+		ctx, cancelCtx = context.WithTimeout(context.Background(), p.options.timeout)
+	} else {
+		ctx, cancelCtx = context.WithCancel(context.Background())
+	}
+	defer cancelCtx()
+	if cancel != nil {
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-cancel:
+				cancelCtx()
+			case <-done:
+			}
+		}()
+	}
+	if p.options.method == PushMethodAdd {
+		return p.pusher.AddContext(ctx)
+	}
+	return p.pusher.PushContext(ctx)
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	promModel "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
+	. "github.com/uber/jaeger-lib/metrics/prometheus"
+)
+
+// pushgateway is a minimal stand-in for the Prometheus Pushgateway.
+type pushgateway struct {
+	sync.Mutex
+	failures int
+	requests []pushRequest
+}
+
+type pushRequest struct {
+	method   string
+	path     string
+	families []*promModel.MetricFamily
+}
+
+func (g *pushgateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.Lock()
+	defer g.Unlock()
+	decoder := expfmt.NewDecoder(r.Body, expfmt.ResponseFormat(r.Header))
+	var families []*promModel.MetricFamily
+	for {
+		mf := new(promModel.MetricFamily)
+		if err := decoder.Decode(mf); err != nil {
+			break
+		}
+		families = append(families, mf)
+	}
+	g.requests = append(g.requests, pushRequest{method: r.Method, path: r.URL.Path, families: families})
+	if g.failures > 0 {
+		g.failures--
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (g *pushgateway) snapshot() []pushRequest {
+	g.Lock()
+	defer g.Unlock()
+	return append([]pushRequest(nil), g.requests...)
+}
+
+func TestPusher(t *testing.T) {
+	testCases := []struct {
+		method         PushMethod
+		expectedMethod string
+	}{
+		{method: PushMethodReplace, expectedMethod: http.MethodPut},
+		{method: PushMethodAdd, expectedMethod: http.MethodPost},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.expectedMethod, func(t *testing.T) {
+			gateway := &pushgateway{}
+			server := httptest.NewServer(gateway)
+			defer server.Close()
+
+			registry := prometheus.NewPedanticRegistry()
+			f := New(WithRegisterer(registry))
+			p, err := NewPusher(f, server.URL, "batch",
+				WithPushInterval(0),
+				WithPushMethod(testCase.method),
+				WithPushGrouping("instance", "host-1"),
+			)
+			require.NoError(t, err)
+			f.Counter(metrics.Options{
+				Name: "rodriguez",
+				Tags: map[string]string{"x": "y"},
+			}).Inc(3)
+
+			require.NoError(t, p.Push())
+			require.NoError(t, p.Close())
+			require.NoError(t, p.Close(), "second Close is a no-op")
+
+			requests := gateway.snapshot()
+			require.Len(t, requests, 2)
+			for _, r := range requests {
+				assert.Equal(t, testCase.expectedMethod, r.method)
+				assert.Equal(t, "/metrics/job/batch/instance/host-1", r.path)
+				m := findMetric(t, r.families, "rodriguez_total", map[string]string{"x": "y"})
+				assert.EqualValues(t, 3, m.GetCounter().GetValue())
+			}
+		})
+	}
+}
+
+func TestPusherInterval(t *testing.T) {
+	gateway := &pushgateway{}
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+
+	f := New(WithRegisterer(prometheus.NewPedanticRegistry()))
+	p, err := NewPusher(f, server.URL, "batch", WithPushInterval(time.Millisecond))
+	require.NoError(t, err)
+	f.Gauge(metrics.Options{Name: "gauge"}).Update(42)
+
+	for i := 0; i < 1000 && len(gateway.snapshot()) < 2; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	assert.True(t, len(gateway.snapshot()) >= 2, "expected periodic pushes")
+
+	require.NoError(t, p.Close())
+	requests := gateway.snapshot()
+	m := findMetric(t, requests[len(requests)-1].families, "gauge", map[string]string{})
+	assert.EqualValues(t, 42, m.GetGauge().GetValue())
+}
+
+func TestPusherRetries(t *testing.T) {
+	gateway := &pushgateway{failures: 2}
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+
+	f := New(WithRegisterer(prometheus.NewPedanticRegistry()))
+	p, err := NewPusher(f, server.URL, "batch",
+		WithPushInterval(0),
+		WithPushRetries(2, time.Millisecond, 2*time.Millisecond),
+	)
+	require.NoError(t, err)
+	require.NoError(t, p.Push())
+	assert.Len(t, gateway.snapshot(), 3)
+
+	gateway.Lock()
+	gateway.failures = 3
+	gateway.Unlock()
+	assert.Error(t, p.Push())
+	assert.Len(t, gateway.snapshot(), 6)
+}
+
+func TestPusherErrorHandler(t *testing.T) {
+	gateway := &pushgateway{failures: 1000}
+	server := httptest.NewServer(gateway)
+	defer server.Close()
+
+	errs := make(chan error, 1000)
+	f := New(WithRegisterer(prometheus.NewPedanticRegistry()))
+	p, err := NewPusher(f, server.URL, "batch",
+		WithPushInterval(time.Millisecond),
+		WithPushRetries(0, 0, 0),
+		WithPushErrorHandler(func(err error) { errs <- err }),
+	)
+	require.NoError(t, err)
+	select {
+	case err := <-errs:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a push error")
+	}
+	assert.Error(t, p.Close())
+}
+
+type registererOnly struct {
+	prometheus.Registerer
+}
+
+func TestPusherRequiresGatherer(t *testing.T) {
+	f := New(WithRegisterer(registererOnly{prometheus.NewRegistry()}))
+	_, err := NewPusher(f, "http://localhost:9091", "batch")
+	assert.Error(t, err)
+}
+
+func TestPusherTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	f := New(WithRegisterer(prometheus.NewPedanticRegistry()))
+	p, err := NewPusher(f, server.URL, "batch",
+		WithPushInterval(0),
+		WithPushRetries(0, 0, 0),
+		WithPushTimeout(10*time.Millisecond),
+	)
+	require.NoError(t, err)
+	start := time.Now()
+	assert.Error(t, p.Push())
+	assert.True(t, time.Since(start) < 5*time.Second)
+}
+
+func TestPusherCloseCancelsPush(t *testing.T) {
+	var requests int32
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			// hang the first push until its request is cancelled
+			close(started)
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	f := New(WithRegisterer(prometheus.NewPedanticRegistry()))
+	p, err := NewPusher(f, server.URL, "batch",
+		WithPushInterval(time.Millisecond),
+		WithPushRetries(0, 0, 0),
+		WithPushTimeout(0),
+	)
+	require.NoError(t, err)
+	<-started
+	// without a timeout, only closing the pusher can abort the hanging push
+	assert.NoError(t, p.Close())
+	assert.True(t, atomic.LoadInt32(&requests) >= 2)
+}
@@ -0,0 +1,329 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+const (
+	defaultRemoteWriteInterval   = 15 * time.Second
+	defaultRemoteWriteQueueSize  = 100
+	defaultRemoteWriteMaxSamples = 2000
+	defaultRemoteWriteTimeout    = 10 * time.Second
+	defaultRemoteWriteRetries    = 3
+	defaultRemoteWriteBackoff    = 100 * time.Millisecond
+	defaultRemoteWriteMaxBackoff = 5 * time.Second
+)
+
+// RemoteWriter periodically gathers the registry behind a Factory and sends it
+// to a Prometheus remote-write endpoint as snappy-compressed protobuf. It is meant
+// for agents that cannot be scraped. Requests are queued in a bounded queue and sent
+// by a single goroutine; when the queue is full the oldest request is dropped.
+type RemoteWriter struct {
+	endpoint string
+	gatherer prometheus.Gatherer
+	options  remoteWriteOptions
+	metrics  remoteWriteMetrics
+	queue    chan writeRequest
+	stop     chan struct{}
+	gatherWG sync.WaitGroup
+	sendWG   sync.WaitGroup
+	once     sync.Once
+	timeNow  func() time.Time
+}
+
+// writeRequest is a compressed WriteRequest waiting to be sent.
+type writeRequest struct {
+	body    []byte
+	samples int
+}
+
+// remoteWriteMetrics are the metrics the RemoteWriter reports about itself,
+// through the same Factory whose registry it sends.
+type remoteWriteMetrics struct {
+	SamplesSent    metrics.Counter `metric:"samples_sent" help:"Number of samples successfully sent"`
+	RequestsOK     metrics.Counter `metric:"requests" tags:"result=ok" help:"Number of remote-write requests"`
+	RequestsErr    metrics.Counter `metric:"requests" tags:"result=err" help:"Number of remote-write requests"`
+	Retries        metrics.Counter `metric:"retries" help:"Number of retried remote-write requests"`
+	DroppedQueue   metrics.Counter `metric:"dropped_requests" tags:"reason=queue_full" help:"Number of requests dropped without being sent"`
+	DroppedRetries metrics.Counter `metric:"dropped_requests" tags:"reason=send_failed" help:"Number of requests dropped without being sent"`
+	GatherErrors   metrics.Counter `metric:"gather_errors" help:"Number of failures to gather the registry"`
+	QueueLength    metrics.Gauge   `metric:"queue_length" help:"Number of requests waiting to be sent"`
+	SendLatency    metrics.Timer   `metric:"send_latency" help:"Latency of remote-write requests"`
+}
+
+type remoteWriteOptions struct {
+	interval   time.Duration
+	headers    map[string]string
+	queueSize  int
+	maxSamples int
+	retries    int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	client     *http.Client
+}
+
+// RemoteWriteOption is a function that sets some option for the RemoteWriter constructor.
+type RemoteWriteOption func(*remoteWriteOptions)
+
+// WithRemoteWriteInterval returns an option that sets how often the registry is gathered
+// and sent. If not used, we fallback to 15 seconds.
+func WithRemoteWriteInterval(interval time.Duration) RemoteWriteOption {
+	return func(opts *remoteWriteOptions) {
+		opts.interval = interval
+	}
+}
+
+// WithRemoteWriteHeader returns an option that adds an HTTP header to every request,
+// e.g. for authentication or tenant selection.
+func WithRemoteWriteHeader(name, value string) RemoteWriteOption {
+	return func(opts *remoteWriteOptions) {
+		opts.headers[name] = value
+	}
+}
+
+// WithRemoteWriteQueueSize returns an option that sets how many requests can wait
+// to be sent. If not used, we fallback to 100.
+func WithRemoteWriteQueueSize(size int) RemoteWriteOption {
+	return func(opts *remoteWriteOptions) {
+		opts.queueSize = size
+	}
+}
+
+// WithRemoteWriteMaxSamples returns an option that sets the maximum number of samples
+// in a single request; larger snapshots are split in several requests.
+// If not used, we fallback to 2000.
+func WithRemoteWriteMaxSamples(maxSamples int) RemoteWriteOption {
+	return func(opts *remoteWriteOptions) {
+		opts.maxSamples = maxSamples
+	}
+}
+
+// WithRemoteWriteRetries returns an option that sets how many times a request failing
+// with a network error, a 5xx or a 429 status is retried, and the backoff between
+// attempts, which doubles after each attempt up to maxBackoff. If not used, a request
+// is retried 3 times starting with a 100ms backoff of up to 5 seconds.
+func WithRemoteWriteRetries(retries int, backoff, maxBackoff time.Duration) RemoteWriteOption {
+	return func(opts *remoteWriteOptions) {
+		opts.retries = retries
+		opts.backoff = backoff
+		opts.maxBackoff = maxBackoff
+	}
+}
+
+// WithRemoteWriteClient returns an option that sets the HTTP client used for sending.
+// If not used, we fallback to a client with a 10 seconds timeout.
+func WithRemoteWriteClient(client *http.Client) RemoteWriteOption {
+	return func(opts *remoteWriteOptions) {
+		opts.client = client
+	}
+}
+
+func applyRemoteWriteOptions(opts []RemoteWriteOption) remoteWriteOptions {
+	options := remoteWriteOptions{
+		interval:   defaultRemoteWriteInterval,
+		headers:    make(map[string]string),
+		queueSize:  defaultRemoteWriteQueueSize,
+		maxSamples: defaultRemoteWriteMaxSamples,
+		retries:    defaultRemoteWriteRetries,
+		backoff:    defaultRemoteWriteBackoff,
+		maxBackoff: defaultRemoteWriteMaxBackoff,
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+	if options.client == nil {
+		options.client = &http.Client{Timeout: defaultRemoteWriteTimeout}
+	}
+	if options.queueSize < 1 {
+		options.queueSize = 1
+	}
+	return options
+}
+
+// NewRemoteWriter creates a RemoteWriter that sends the metrics registered by the factory
+// to the remote-write endpoint, and starts it. The factory must have been created
+// with a registerer that is also a prometheus.Gatherer, which is the case
+// for *prometheus.Registry and prometheus.DefaultRegisterer.
+// The RemoteWriter reports its own metrics under the "remote_write" namespace of the factory.
+func NewRemoteWriter(factory *Factory, endpoint string, opts ...RemoteWriteOption) (*RemoteWriter, error) {
+	gatherer, ok := factory.cache.registerer.(prometheus.Gatherer)
+	if !ok {
+		return nil, errNotGatherer
+	}
+	options := applyRemoteWriteOptions(opts)
+	w := &RemoteWriter{
+		endpoint: endpoint,
+		gatherer: gatherer,
+		options:  options,
+		queue:    make(chan writeRequest, options.queueSize),
+		stop:     make(chan struct{}),
+		timeNow:  time.Now,
+	}
+	if err := metrics.Init(&w.metrics, factory.Namespace(metrics.NSOptions{Name: "remote_write"}), nil); err != nil {
+		return nil, err
+	}
+	w.gatherWG.Add(1)
+	go w.gatherLoop()
+	w.sendWG.Add(1)
+	go w.sendLoop()
+	return w, nil
+}
+
+// Close stops gathering, sends a final snapshot and waits for the queued
+// requests to be sent. Failed requests are no longer retried once closing.
+func (w *RemoteWriter) Close() error {
+	w.once.Do(func() {
+		close(w.stop)
+		w.gatherWG.Wait()
+		w.gather()
+		close(w.queue)
+		w.sendWG.Wait()
+	})
+	return nil
+}
+
+func (w *RemoteWriter) gatherLoop() {
+	defer w.gatherWG.Done()
+	ticker := time.NewTicker(w.options.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.gather()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// gather takes a snapshot of the registry and queues it as one or more requests.
+func (w *RemoteWriter) gather() {
+	families, err := w.gatherer.Gather()
+	if err != nil {
+		// Gather returns as many metrics as it could collect alongside the error
+		w.metrics.GatherErrors.Inc(1)
+	}
+	series := toTimeSeries(families, w.timeNow().UnixNano()/int64(time.Millisecond))
+	for len(series) > 0 {
+		n := w.options.maxSamples
+		if n <= 0 || n > len(series) {
+			n = len(series)
+		}
+		w.enqueue(writeRequest{
+			body:    snappy.Encode(nil, encodeWriteRequest(series[:n])),
+			samples: n,
+		})
+		series = series[n:]
+	}
+}
+
+// enqueue adds a request to the queue, dropping the oldest request when it is full.
+func (w *RemoteWriter) enqueue(request writeRequest) {
+	for {
+		select {
+		case w.queue <- request:
+			w.metrics.QueueLength.Update(int64(len(w.queue)))
+			return
+		default:
+		}
+		select {
+		case <-w.queue:
+			w.metrics.DroppedQueue.Inc(1)
+		default:
+		}
+	}
+}
+
+func (w *RemoteWriter) sendLoop() {
+	defer w.sendWG.Done()
+	for request := range w.queue {
+		w.metrics.QueueLength.Update(int64(len(w.queue)))
+		if err := w.sendWithRetries(request.body); err != nil {
+			w.metrics.DroppedRetries.Inc(1)
+			continue
+		}
+		w.metrics.SamplesSent.Inc(int64(request.samples))
+	}
+}
+
+// sendWithRetries sends the request, retrying with exponential backoff.
+// Retrying is abandoned when the writer is closed.
+func (w *RemoteWriter) sendWithRetries(request []byte) error {
+	backoff := w.options.backoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := w.send(request)
+		if err == nil {
+			w.metrics.RequestsOK.Inc(1)
+			return nil
+		}
+		w.metrics.RequestsErr.Inc(1)
+		if !retryable || attempt >= w.options.retries {
+			return err
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-w.stop:
+			timer.Stop()
+			return err
+		}
+		w.metrics.Retries.Inc(1)
+		backoff *= 2
+		if backoff > w.options.maxBackoff {
+			backoff = w.options.maxBackoff
+		}
+	}
+}
+
+// send posts a single request and reports whether a failure can be retried.
+func (w *RemoteWriter) send(request []byte) (retryable bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, w.endpoint, bytes.NewReader(request))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", "jaeger-lib-remote-write")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	for name, value := range w.options.headers {
+		req.Header.Set(name, value)
+	}
+	sw := metrics.StartStopwatch(w.metrics.SendLatency)
+	resp, err := w.options.client.Do(req)
+	sw.Stop()
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	err = fmt.Errorf("remote-write endpoint returned HTTP status %s", resp.Status)
+	return resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests, err
+}
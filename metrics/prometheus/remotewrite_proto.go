@@ -0,0 +1,220 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	promModel "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The remote-write protocol is defined by the following protobuf messages
+// (see prompb in github.com/prometheus/prometheus), which are encoded
+// by hand to avoid depending on the Prometheus server module:
+//
+//	message WriteRequest { repeated TimeSeries timeseries = 1; }
+//	message TimeSeries   { repeated Label labels = 1; repeated Sample samples = 2; repeated Histogram histograms = 4; }
+//	message Label        { string name = 1; string value = 2; }
+//	message Sample       { double value = 1; int64 timestamp = 2; }
+//	message Histogram    {
+//	  uint64 count_int = 1; double sum = 3; sint32 schema = 4; double zero_threshold = 5;
+//	  uint64 zero_count_int = 6; repeated BucketSpan negative_spans = 8; repeated sint64 negative_deltas = 9;
+//	  repeated BucketSpan positive_spans = 11; repeated sint64 positive_deltas = 12; int64 timestamp = 15;
+//	}
+//	message BucketSpan   { sint32 offset = 1; uint32 length = 2; }
+
+type label struct {
+	name  string
+	value string
+}
+
+// timeSeries holds a single float sample, or a native histogram sample
+// when histogram is set.
+type timeSeries struct {
+	labels    []label
+	value     float64
+	histogram *promModel.Histogram
+	timestamp int64
+}
+
+// toTimeSeries flattens gathered metric families into remote-write time series,
+// expanding histograms and summaries the same way the text exposition format does.
+// Native histograms are additionally sent as a histogram sample under the metric name.
+// Metrics without an explicit timestamp get the timestamp now, in milliseconds.
+func toTimeSeries(families []*promModel.MetricFamily, now int64) []timeSeries {
+	var series []timeSeries
+	for _, mf := range families {
+		name := mf.GetName()
+		for _, m := range mf.GetMetric() {
+			ts := now
+			if m.TimestampMs != nil {
+				ts = m.GetTimestampMs()
+			}
+			add := func(name string, value float64, extra ...label) {
+				series = append(series, timeSeries{
+					labels:    seriesLabels(name, m.GetLabel(), extra...),
+					value:     value,
+					timestamp: ts,
+				})
+			}
+			switch mf.GetType() {
+			case promModel.MetricType_COUNTER:
+				add(name, m.GetCounter().GetValue())
+			case promModel.MetricType_GAUGE:
+				add(name, m.GetGauge().GetValue())
+			case promModel.MetricType_UNTYPED:
+				add(name, m.GetUntyped().GetValue())
+			case promModel.MetricType_SUMMARY:
+				s := m.GetSummary()
+				for _, q := range s.GetQuantile() {
+					add(name, q.GetValue(), label{name: "quantile", value: formatFloat(q.GetQuantile())})
+				}
+				add(name+"_sum", s.GetSampleSum())
+				add(name+"_count", float64(s.GetSampleCount()))
+			case promModel.MetricType_HISTOGRAM:
+				h := m.GetHistogram()
+				for _, b := range h.GetBucket() {
+					if math.IsInf(b.GetUpperBound(), +1) {
+						continue
+					}
+					add(name+"_bucket", float64(b.GetCumulativeCount()), label{name: "le", value: formatFloat(b.GetUpperBound())})
+				}
+				add(name+"_bucket", float64(h.GetSampleCount()), label{name: "le", value: "+Inf"})
+				add(name+"_sum", h.GetSampleSum())
+				add(name+"_count", float64(h.GetSampleCount()))
+				if isNativeHistogram(h) {
+					series = append(series, timeSeries{
+						labels:    seriesLabels(name, m.GetLabel()),
+						histogram: h,
+						timestamp: ts,
+					})
+				}
+			}
+		}
+	}
+	return series
+}
+
+// seriesLabels returns the labels of a series, including the metric name,
+// sorted by label name as required by the remote-write protocol.
+func seriesLabels(name string, pairs []*promModel.LabelPair, extra ...label) []label {
+	labels := make([]label, 0, len(pairs)+len(extra)+1)
+	labels = append(labels, label{name: "__name__", value: name})
+	for _, p := range pairs {
+		labels = append(labels, label{name: p.GetName(), value: p.GetValue()})
+	}
+	labels = append(labels, extra...)
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].name < labels[j].name
+	})
+	return labels
+}
+
+// isNativeHistogram reports whether the histogram carries native buckets.
+// A native histogram always has a zero bucket threshold or populated spans.
+func isNativeHistogram(h *promModel.Histogram) bool {
+	return h.GetZeroThreshold() > 0 || h.GetZeroCount() > 0 ||
+		len(h.GetPositiveSpan()) > 0 || len(h.GetNegativeSpan()) > 0
+}
+
+func formatFloat(v float64) string {
+	if math.IsInf(v, +1) {
+		return "+Inf"
+	}
+	if math.IsInf(v, -1) {
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// encodeWriteRequest encodes the series as a protobuf WriteRequest message.
+func encodeWriteRequest(series []timeSeries) []byte {
+	var buf, ts, msg []byte
+	for _, s := range series {
+		ts = ts[:0]
+		for _, l := range s.labels {
+			msg = msg[:0]
+			msg = protowire.AppendTag(msg, 1, protowire.BytesType)
+			msg = protowire.AppendString(msg, l.name)
+			msg = protowire.AppendTag(msg, 2, protowire.BytesType)
+			msg = protowire.AppendString(msg, l.value)
+			ts = protowire.AppendTag(ts, 1, protowire.BytesType)
+			ts = protowire.AppendBytes(ts, msg)
+		}
+		if s.histogram != nil {
+			ts = protowire.AppendTag(ts, 4, protowire.BytesType)
+			ts = protowire.AppendBytes(ts, encodeHistogram(msg[:0], s.histogram, s.timestamp))
+		} else {
+			msg = msg[:0]
+			msg = protowire.AppendTag(msg, 1, protowire.Fixed64Type)
+			msg = protowire.AppendFixed64(msg, math.Float64bits(s.value))
+			msg = protowire.AppendTag(msg, 2, protowire.VarintType)
+			msg = protowire.AppendVarint(msg, uint64(s.timestamp))
+			ts = protowire.AppendTag(ts, 2, protowire.BytesType)
+			ts = protowire.AppendBytes(ts, msg)
+		}
+
+		buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, ts)
+	}
+	return buf
+}
+
+// encodeHistogram appends a native histogram as a protobuf Histogram message to b.
+// The client model and the remote-write protocol share the same span and
+// delta-encoded bucket layout, so buckets are copied as they are.
+func encodeHistogram(b []byte, h *promModel.Histogram, timestamp int64) []byte {
+	b = protowire.AppendTag(b, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, h.GetSampleCount())
+	b = protowire.AppendTag(b, 3, protowire.Fixed64Type)
+	b = protowire.AppendFixed64(b, math.Float64bits(h.GetSampleSum()))
+	b = protowire.AppendTag(b, 4, protowire.VarintType)
+	b = protowire.AppendVarint(b, protowire.EncodeZigZag(int64(h.GetSchema())))
+	b = protowire.AppendTag(b, 5, protowire.Fixed64Type)
+	b = protowire.AppendFixed64(b, math.Float64bits(h.GetZeroThreshold()))
+	b = protowire.AppendTag(b, 6, protowire.VarintType)
+	b = protowire.AppendVarint(b, h.GetZeroCount())
+	b = appendBuckets(b, 8, 9, h.GetNegativeSpan(), h.GetNegativeDelta())
+	b = appendBuckets(b, 11, 12, h.GetPositiveSpan(), h.GetPositiveDelta())
+	b = protowire.AppendTag(b, 15, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(timestamp))
+	return b
+}
+
+// appendBuckets appends the spans and the packed deltas of one side of a native histogram.
+func appendBuckets(b []byte, spansField, deltasField protowire.Number, spans []*promModel.BucketSpan, deltas []int64) []byte {
+	var msg []byte
+	for _, s := range spans {
+		msg = msg[:0]
+		msg = protowire.AppendTag(msg, 1, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, protowire.EncodeZigZag(int64(s.GetOffset())))
+		msg = protowire.AppendTag(msg, 2, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, uint64(s.GetLength()))
+		b = protowire.AppendTag(b, spansField, protowire.BytesType)
+		b = protowire.AppendBytes(b, msg)
+	}
+	if len(deltas) > 0 {
+		msg = msg[:0]
+		for _, d := range deltas {
+			msg = protowire.AppendVarint(msg, protowire.EncodeZigZag(d))
+		}
+		b = protowire.AppendTag(b, deltasField, protowire.BytesType)
+		b = protowire.AppendBytes(b, msg)
+	}
+	return b
+}
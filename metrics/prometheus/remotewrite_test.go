@@ -0,0 +1,412 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/uber/jaeger-lib/metrics"
+	. "github.com/uber/jaeger-lib/metrics/prometheus"
+)
+
+// remoteWriteReceiver is a minimal remote-write endpoint that decodes
+// the requests into a map of "name{labels}" to sample values.
+type remoteWriteReceiver struct {
+	sync.Mutex
+	statuses []int
+	requests []*http.Request
+	samples  map[string]float64
+	batches  []int
+}
+
+func (r *remoteWriteReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.Lock()
+	defer r.Unlock()
+	r.requests = append(r.requests, req)
+	if len(r.statuses) > 0 {
+		status := r.statuses[0]
+		r.statuses = r.statuses[1:]
+		w.WriteHeader(status)
+		return
+	}
+	compressed, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	body, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	series := decodeWriteRequest(body)
+	if r.samples == nil {
+		r.samples = make(map[string]float64)
+	}
+	for k, v := range series {
+		r.samples[k] = v
+	}
+	r.batches = append(r.batches, len(series))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *remoteWriteReceiver) sample(key string) (float64, bool) {
+	r.Lock()
+	defer r.Unlock()
+	v, ok := r.samples[key]
+	return v, ok
+}
+
+func (r *remoteWriteReceiver) requestCount() int {
+	r.Lock()
+	defer r.Unlock()
+	return len(r.requests)
+}
+
+func decodeWriteRequest(b []byte) map[string]float64 {
+	series := make(map[string]float64)
+	forEachField(b, func(num protowire.Number, ts []byte) {
+		var name string
+		var labels []string
+		var value float64
+		forEachField(ts, func(num protowire.Number, msg []byte) {
+			if num == 1 {
+				var k, v string
+				forEachField(msg, func(num protowire.Number, s []byte) {
+					if num == 1 {
+						k = string(s)
+					} else {
+						v = string(s)
+					}
+				})
+				if k == "__name__" {
+					name = v
+				} else {
+					labels = append(labels, k+"="+v)
+				}
+				return
+			}
+			if num == 4 {
+				// native histograms are reduced to their sample count
+				value = float64(histogramCount(msg))
+				return
+			}
+			forEachField(msg, func(num protowire.Number, v []byte) {
+				if num == 1 {
+					bits, _ := protowire.ConsumeFixed64(v)
+					value = math.Float64frombits(bits)
+				}
+			})
+		})
+		sort.Strings(labels)
+		series[name+"{"+strings.Join(labels, ",")+"}"] = value
+	})
+	return series
+}
+
+// histogramCount returns the count_int field of a native Histogram message.
+func histogramCount(b []byte) uint64 {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		b = b[n:]
+		if num == 1 && typ == protowire.VarintType {
+			v, _ := protowire.ConsumeVarint(b)
+			return v
+		}
+		b = b[protowire.ConsumeFieldValue(num, typ, b):]
+	}
+	return 0
+}
+
+// forEachField calls fn with the payload of every field in the message;
+// for the fixed64 sample value the payload is the raw 8 bytes.
+func forEachField(b []byte, fn func(protowire.Number, []byte)) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		b = b[n:]
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			fn(num, v)
+			b = b[n:]
+		case protowire.Fixed64Type:
+			fn(num, b[:8])
+			b = b[8:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			b = b[n:]
+		}
+	}
+}
+
+func TestRemoteWriter(t *testing.T) {
+	receiver := &remoteWriteReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	registry := prometheus.NewPedanticRegistry()
+	f := New(WithRegisterer(registry), WithBuckets([]float64{1, 2}))
+	w, err := NewRemoteWriter(f, server.URL,
+		WithRemoteWriteInterval(time.Hour),
+		WithRemoteWriteHeader("X-Scope-OrgID", "tenant-1"),
+	)
+	require.NoError(t, err)
+
+	f.Counter(metrics.Options{Name: "counter", Tags: map[string]string{"x": "y"}}).Inc(3)
+	f.Gauge(metrics.Options{Name: "gauge"}).Update(42)
+	h := f.Histogram(metrics.HistogramOptions{Name: "histogram"})
+	h.Record(1)
+	h.Record(5)
+	require.NoError(t, w.Close())
+
+	expected := map[string]float64{
+		"counter_total{x=y}":        3,
+		"gauge{}":                   42,
+		"histogram_bucket{le=1}":    1,
+		"histogram_bucket{le=2}":    1,
+		"histogram_bucket{le=+Inf}": 2,
+		"histogram_sum{}":           6,
+		"histogram_count{}":         2,
+	}
+	for key, value := range expected {
+		v, ok := receiver.sample(key)
+		if assert.True(t, ok, "missing sample %s", key) {
+			assert.EqualValues(t, value, v, key)
+		}
+	}
+
+	receiver.Lock()
+	req := receiver.requests[0]
+	receiver.Unlock()
+	assert.Equal(t, "snappy", req.Header.Get("Content-Encoding"))
+	assert.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+	assert.Equal(t, "0.1.0", req.Header.Get("X-Prometheus-Remote-Write-Version"))
+	assert.Equal(t, "tenant-1", req.Header.Get("X-Scope-OrgID"))
+
+	snapshot, err := registry.Gather()
+	require.NoError(t, err)
+	m := findMetric(t, snapshot, "remote_write_requests_total", map[string]string{"result": "ok"})
+	assert.EqualValues(t, 1, m.GetCounter().GetValue())
+	m = findMetric(t, snapshot, "remote_write_samples_sent_total", map[string]string{})
+	assert.True(t, m.GetCounter().GetValue() > 7)
+}
+
+func TestRemoteWriterSummary(t *testing.T) {
+	receiver := &remoteWriteReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	f := New(
+		WithRegisterer(prometheus.NewPedanticRegistry()),
+		WithTimerType(TimerTypeSummary, map[float64]float64{0.5: 0.05}, 0),
+	)
+	w, err := NewRemoteWriter(f, server.URL, WithRemoteWriteInterval(time.Hour))
+	require.NoError(t, err)
+	f.Timer(metrics.TimerOptions{Name: "timer"}).Record(2 * time.Second)
+	require.NoError(t, w.Close())
+
+	v, ok := receiver.sample("timer{quantile=0.5}")
+	require.True(t, ok)
+	assert.EqualValues(t, 2, v)
+	v, ok = receiver.sample("timer_count{}")
+	require.True(t, ok)
+	assert.EqualValues(t, 1, v)
+}
+
+func TestRemoteWriterNativeHistogram(t *testing.T) {
+	receiver := &remoteWriteReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	f := New(
+		WithRegisterer(prometheus.NewPedanticRegistry()),
+		WithNativeHistograms(NativeHistogramOptions{BucketFactor: 1.1}),
+	)
+	w, err := NewRemoteWriter(f, server.URL, WithRemoteWriteInterval(time.Hour))
+	require.NoError(t, err)
+	h := f.Histogram(metrics.HistogramOptions{Name: "histogram"})
+	h.Record(1)
+	h.Record(5)
+	h.Record(-3)
+	require.NoError(t, w.Close())
+
+	v, ok := receiver.sample("histogram{}")
+	require.True(t, ok, "missing native histogram")
+	assert.EqualValues(t, 3, v)
+	v, ok = receiver.sample("histogram_count{}")
+	require.True(t, ok)
+	assert.EqualValues(t, 3, v)
+}
+
+func TestRemoteWriterMaxSamples(t *testing.T) {
+	receiver := &remoteWriteReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	f := New(WithRegisterer(prometheus.NewPedanticRegistry()))
+	for i := 0; i < 10; i++ {
+		f.Gauge(metrics.Options{Name: fmt.Sprintf("gauge_%d", i)}).Update(int64(i))
+	}
+	w, err := NewRemoteWriter(f, server.URL,
+		WithRemoteWriteInterval(time.Hour),
+		WithRemoteWriteMaxSamples(3),
+	)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	receiver.Lock()
+	defer receiver.Unlock()
+	require.True(t, len(receiver.batches) > 3)
+	for _, batch := range receiver.batches {
+		assert.True(t, batch <= 3, "batch of %d samples", batch)
+	}
+	assert.EqualValues(t, 9, receiver.samples["gauge_9{}"])
+}
+
+func TestRemoteWriterRetries(t *testing.T) {
+	testCases := []struct {
+		name     string
+		statuses []int
+		retries  float64
+		dropped  float64
+	}{
+		{name: "retry on 5xx", statuses: []int{500, 503}, retries: 2},
+		{name: "retry on 429", statuses: []int{429}, retries: 1},
+		{name: "no retry on 4xx", statuses: []int{400}, retries: 0, dropped: 1},
+		{name: "retries exhausted", statuses: []int{500, 500, 500}, retries: 2, dropped: 1},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			receiver := &remoteWriteReceiver{statuses: testCase.statuses}
+			server := httptest.NewServer(receiver)
+			defer server.Close()
+
+			registry := prometheus.NewPedanticRegistry()
+			f := New(WithRegisterer(registry))
+			w, err := NewRemoteWriter(f, server.URL,
+				WithRemoteWriteInterval(time.Millisecond),
+				WithRemoteWriteRetries(2, time.Millisecond, time.Millisecond),
+			)
+			require.NoError(t, err)
+			// wait for the failing statuses to be consumed and a request to succeed
+			for i := 0; i < 5000 && receiver.requestCount() <= len(testCase.statuses); i++ {
+				time.Sleep(time.Millisecond)
+			}
+			require.NoError(t, w.Close())
+
+			snapshot, err := registry.Gather()
+			require.NoError(t, err)
+			m := findMetric(t, snapshot, "remote_write_retries_total", map[string]string{})
+			assert.EqualValues(t, testCase.retries, m.GetCounter().GetValue())
+			m = findMetric(t, snapshot, "remote_write_dropped_requests_total", map[string]string{"reason": "send_failed"})
+			assert.EqualValues(t, testCase.dropped, m.GetCounter().GetValue())
+		})
+	}
+}
+
+func TestRemoteWriterCloseAbortsRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	f := New(WithRegisterer(prometheus.NewPedanticRegistry()))
+	f.Gauge(metrics.Options{Name: "gauge"}).Update(1)
+	w, err := NewRemoteWriter(f, server.URL,
+		WithRemoteWriteInterval(time.Millisecond),
+		WithRemoteWriteRetries(10, time.Hour, time.Hour),
+	)
+	require.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		w.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close is blocked by the retry backoff")
+	}
+}
+
+func TestRemoteWriterQueueFull(t *testing.T) {
+	release := make(chan struct{})
+	var once sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	defer once.Do(func() { close(release) })
+
+	registry := prometheus.NewPedanticRegistry()
+	f := New(WithRegisterer(registry))
+	f.Gauge(metrics.Options{Name: "gauge"}).Update(1)
+	w, err := NewRemoteWriter(f, server.URL,
+		WithRemoteWriteInterval(time.Millisecond),
+		WithRemoteWriteQueueSize(1),
+	)
+	require.NoError(t, err)
+
+	dropped := func() float64 {
+		snapshot, err := registry.Gather()
+		require.NoError(t, err)
+		for _, mf := range snapshot {
+			if mf.GetName() != "remote_write_dropped_requests_total" {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				if m.GetLabel()[0].GetValue() == "queue_full" {
+					return m.GetCounter().GetValue()
+				}
+			}
+		}
+		return 0
+	}
+	for i := 0; i < 1000 && dropped() == 0; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	assert.True(t, dropped() > 0, "expected dropped requests")
+
+	once.Do(func() { close(release) })
+	require.NoError(t, w.Close())
+}
+
+type gathererlessRegisterer struct {
+	prometheus.Registerer
+}
+
+func TestRemoteWriterRequiresGatherer(t *testing.T) {
+	f := New(WithRegisterer(gathererlessRegisterer{prometheus.NewRegistry()}))
+	_, err := NewRemoteWriter(f, "http://localhost:9090/api/v1/write")
+	assert.Error(t, err)
+}
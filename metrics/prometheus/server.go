@@ -0,0 +1,164 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const defaultServerPath = "/metrics"
+
+// Server exposes the metrics created by its Factory over HTTP.
+// It owns a dedicated registry with the Go and process collectors registered.
+type Server struct {
+	factory  *Factory
+	registry *prometheus.Registry
+	handler  http.Handler
+	server   *http.Server
+	listener net.Listener
+	serveErr chan error
+}
+
+type serverOptions struct {
+	path           string
+	openMetrics    bool
+	gzip           bool
+	factoryOptions []Option
+}
+
+// ServerOption is a function that sets some option for the Server constructor.
+type ServerOption func(*serverOptions)
+
+// WithPath returns an option that sets the HTTP path metrics are exposed on.
+// If not used, we fallback to "/metrics".
+func WithPath(path string) ServerOption {
+	return func(opts *serverOptions) {
+		opts.path = path
+	}
+}
+
+// WithOpenMetrics returns an option that enables the OpenMetrics exposition
+// format for clients that ask for it in the Accept header.
+// If not used, only the Prometheus text and protobuf formats are served.
+func WithOpenMetrics(enabled bool) ServerOption {
+	return func(opts *serverOptions) {
+		opts.openMetrics = enabled
+	}
+}
+
+// WithGzip returns an option that controls whether responses are gzip compressed
+// for clients that accept it. If not used, compression is enabled.
+func WithGzip(enabled bool) ServerOption {
+	return func(opts *serverOptions) {
+		opts.gzip = enabled
+	}
+}
+
+// WithFactoryOptions returns an option that passes options to the Factory created
+// by the server. WithRegisterer is overridden by the server's own registry.
+func WithFactoryOptions(options ...Option) ServerOption {
+	return func(opts *serverOptions) {
+		opts.factoryOptions = append(opts.factoryOptions, options...)
+	}
+}
+
+func applyServerOptions(opts []ServerOption) *serverOptions {
+	options := &serverOptions{
+		path: defaultServerPath,
+		gzip: true,
+	}
+	for _, o := range opts {
+		o(options)
+	}
+	return options
+}
+
+// NewServer creates a registry with the Go and process collectors, a Factory backed
+// by that registry, and starts serving the registry over HTTP in the background
+// on address, e.g. ":8080", or "127.0.0.1:0" for a random port.
+// Call Shutdown to stop the server.
+func NewServer(address string, opts ...ServerOption) (*Server, error) {
+	options := applyServerOptions(opts)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	factory := New(append(options.factoryOptions, WithRegisterer(registry))...)
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		EnableOpenMetrics:  options.openMetrics,
+		DisableCompression: !options.gzip,
+	})
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle(options.path, handler)
+
+	s := &Server{
+		factory:  factory,
+		registry: registry,
+		handler:  handler,
+		server:   &http.Server{Handler: mux},
+		listener: listener,
+		serveErr: make(chan error, 1),
+	}
+	go func() {
+		if err := s.server.Serve(listener); err != http.ErrServerClosed {
+			s.serveErr <- err
+		}
+		close(s.serveErr)
+	}()
+	return s, nil
+}
+
+// Factory returns the metrics factory whose metrics are exposed by the server.
+func (s *Server) Factory() *Factory {
+	return s.factory
+}
+
+// Handler returns the HTTP handler exposing the registry, which can also be
+// mounted on another mux.
+func (s *Server) Handler() http.Handler {
+	return s.handler
+}
+
+// Registry returns the registry behind the Factory.
+func (s *Server) Registry() *prometheus.Registry {
+	return s.registry
+}
+
+// Addr returns the address the server is listening on.
+func (s *Server) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Shutdown gracefully stops the server, waiting for active requests
+// to complete until the context expires.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if err := s.server.Shutdown(ctx); err != nil {
+		return err
+	}
+	return <-s.serveErr
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus_test
+
+import (
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
+	. "github.com/uber/jaeger-lib/metrics/prometheus"
+)
+
+func TestServer(t *testing.T) {
+	s, err := NewServer("127.0.0.1:0", WithFactoryOptions(WithSeparator(SeparatorColon)))
+	require.NoError(t, err)
+	defer s.Shutdown(context.Background())
+
+	s.Factory().Namespace(metrics.NSOptions{
+		Name: "bender",
+	}).Counter(metrics.Options{
+		Name: "rodriguez",
+		Tags: map[string]string{"a": "b"},
+	}).Inc(3)
+
+	resp, err := http.Get("http://" + s.Addr().String() + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `bender:rodriguez_total{a="b"} 3`)
+	assert.Contains(t, string(body), "go_goroutines")
+	assert.Contains(t, string(body), "process_start_time_seconds")
+
+	snapshot, err := s.Registry().Gather()
+	require.NoError(t, err)
+	findMetric(t, snapshot, "bender:rodriguez_total", map[string]string{"a": "b"})
+}
+
+func TestServerPath(t *testing.T) {
+	s, err := NewServer("127.0.0.1:0", WithPath("/custom"))
+	require.NoError(t, err)
+	defer s.Shutdown(context.Background())
+
+	resp, err := http.Get("http://" + s.Addr().String() + "/metrics")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, err = http.Get("http://" + s.Addr().String() + "/custom")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestServerOpenMetrics(t *testing.T) {
+	testCases := []struct {
+		openMetrics bool
+		contentType string
+	}{
+		{openMetrics: true, contentType: "application/openmetrics-text"},
+		{openMetrics: false, contentType: "text/plain"},
+	}
+	for _, testCase := range testCases {
+		s, err := NewServer("127.0.0.1:0", WithOpenMetrics(testCase.openMetrics))
+		require.NoError(t, err)
+		s.Factory().Counter(metrics.Options{Name: "counter"}).Inc(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.Header.Set("Accept", "application/openmetrics-text; version=0.0.1")
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, req)
+		assert.Contains(t, rec.Header().Get("Content-Type"), testCase.contentType)
+		if testCase.openMetrics {
+			assert.Contains(t, rec.Body.String(), "# EOF")
+		}
+		require.NoError(t, s.Shutdown(context.Background()))
+	}
+}
+
+func TestServerGzip(t *testing.T) {
+	testCases := []struct {
+		gzip     bool
+		encoding string
+	}{
+		{gzip: true, encoding: "gzip"},
+		{gzip: false, encoding: ""},
+	}
+	for _, testCase := range testCases {
+		s, err := NewServer("127.0.0.1:0", WithGzip(testCase.gzip))
+		require.NoError(t, err)
+		s.Factory().Counter(metrics.Options{Name: "counter"}).Inc(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, req)
+		assert.Equal(t, testCase.encoding, rec.Header().Get("Content-Encoding"))
+		if testCase.gzip {
+			r, err := gzip.NewReader(rec.Body)
+			require.NoError(t, err)
+			body, err := ioutil.ReadAll(r)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), "counter_total 1")
+		}
+		require.NoError(t, s.Shutdown(context.Background()))
+	}
+}
+
+func TestServerShutdown(t *testing.T) {
+	s, err := NewServer("127.0.0.1:0")
+	require.NoError(t, err)
+	addr := s.Addr().String()
+	require.NoError(t, s.Shutdown(context.Background()))
+
+	_, err = http.Get("http://" + addr + "/metrics")
+	assert.Error(t, err)
+}
+
+func TestServerInvalidAddress(t *testing.T) {
+	_, err := NewServer("invalid-address")
+	assert.Error(t, err)
+}
@@ -0,0 +1,358 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sql records metrics of database/sql drivers and connection pools through
+// a metrics.Factory.
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+// The operations, values of the tag "operation".
+const (
+	Query       = "query"
+	Exec        = "exec"
+	Prepare     = "prepare"
+	Begin       = "begin"
+	Commit      = "commit"
+	Rollback    = "rollback"
+	Transaction = "transaction"
+)
+
+var operations = []string{Query, Exec, Prepare, Begin, Commit, Rollback, Transaction}
+
+// driverMetrics holds the metrics of each operation.
+type driverMetrics struct {
+	latency map[string]metrics.Timer
+	errors  map[string]metrics.Counter
+}
+
+func newDriverMetrics(factory metrics.Factory) *driverMetrics {
+	ns := factory.Namespace(metrics.NSOptions{Name: "sql"})
+	m := &driverMetrics{
+		latency: make(map[string]metrics.Timer, len(operations)),
+		errors:  make(map[string]metrics.Counter, len(operations)),
+	}
+	for _, op := range operations {
+		tags := map[string]string{"operation": op}
+		m.latency[op] = ns.Timer(metrics.TimerOptions{Name: "latency", Tags: tags})
+		m.errors[op] = ns.Counter(metrics.Options{Name: "errors", Tags: tags})
+	}
+	return m
+}
+
+// observe records an operation started at start. driver.ErrSkip is not an error
+// but asks database/sql to fallback to another method, and is not recorded.
+func (m *driverMetrics) observe(op string, start time.Time, err error) {
+	if errors.Is(err, driver.ErrSkip) {
+		return
+	}
+	m.latency[op].Record(time.Since(start))
+	if err != nil {
+		m.errors[op].Inc(1)
+	}
+}
+
+// WrapDriver returns a driver recording, in the namespace "sql" of factory, a Timer "latency"
+// and a Counter "errors" tagged with the operation: "query", "exec", "prepare", "begin",
+// "commit", "rollback", and "transaction" from its beginning to its commit or rollback.
+// The latency of queries does not include reading the rows. Statements are recorded
+// when executed, as queries and execs.
+//
+// The wrapped driver can be registered with database/sql under a new name:
+//
+//	sql.Register("instrumented-postgres", xsql.WrapDriver(&pq.Driver{}, factory))
+func WrapDriver(d driver.Driver, factory metrics.Factory) driver.Driver {
+	return &wrappedDriver{Driver: d, metrics: newDriverMetrics(factory)}
+}
+
+// WrapConnector returns a connector whose connections record the metrics described for
+// WrapDriver, to be used with sql.OpenDB.
+func WrapConnector(c driver.Connector, factory metrics.Factory) driver.Connector {
+	d := &wrappedDriver{Driver: c.Driver(), metrics: newDriverMetrics(factory)}
+	return &connector{Connector: c, driver: d}
+}
+
+type wrappedDriver struct {
+	driver.Driver
+	metrics *driverMetrics
+}
+
+func (d *wrappedDriver) Open(name string) (driver.Conn, error) {
+	c, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: c, metrics: d.metrics}, nil
+}
+
+// OpenConnector implements driver.DriverContext, with the connector of the wrapped
+// driver if it has one.
+func (d *wrappedDriver) OpenConnector(name string) (driver.Connector, error) {
+	if dc, ok := d.Driver.(driver.DriverContext); ok {
+		c, err := dc.OpenConnector(name)
+		if err != nil {
+			return nil, err
+		}
+		return &connector{Connector: c, driver: d}, nil
+	}
+	return &dsnConnector{name: name, driver: d}, nil
+}
+
+type connector struct {
+	driver.Connector
+	driver *wrappedDriver
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn2, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: conn2, metrics: c.driver.metrics}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// dsnConnector is the connector of drivers without one, like the one of database/sql.
+type dsnConnector struct {
+	name   string
+	driver *wrappedDriver
+}
+
+func (c *dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.name)
+}
+
+func (c *dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// conn records the operations of a connection, and implements the optional interfaces
+// of driver.Conn by falling back to what database/sql does without them.
+type conn struct {
+	driver.Conn
+	metrics *driverMetrics
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	var rows driver.Rows
+	var err error
+	switch q := c.Conn.(type) {
+	case driver.QueryerContext:
+		rows, err = q.QueryContext(ctx, query, args)
+	case driver.Queryer:
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			rows, err = q.Query(query, values)
+		}
+	default:
+		err = driver.ErrSkip
+	}
+	c.metrics.observe(Query, start, err)
+	return rows, err
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	var result driver.Result
+	var err error
+	switch e := c.Conn.(type) {
+	case driver.ExecerContext:
+		result, err = e.ExecContext(ctx, query, args)
+	case driver.Execer:
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			result, err = e.Exec(query, values)
+		}
+	default:
+		err = driver.ErrSkip
+	}
+	c.metrics.observe(Exec, start, err)
+	return result, err
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	start := time.Now()
+	var s driver.Stmt
+	var err error
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		s, err = p.PrepareContext(ctx, query)
+	} else if s, err = c.Conn.Prepare(query); err == nil && ctx.Err() != nil {
+		s.Close()
+		s, err = nil, ctx.Err()
+	}
+	c.metrics.observe(Prepare, start, err)
+	if err != nil {
+		return nil, err
+	}
+	return &stmt{Stmt: s, conn: c, metrics: c.metrics}, nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	start := time.Now()
+	var tx driver.Tx
+	var err error
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		tx, err = b.BeginTx(ctx, opts)
+	} else if opts.Isolation != 0 {
+		err = errors.New("sql: driver does not support non-default isolation level")
+	} else if opts.ReadOnly {
+		err = errors.New("sql: driver does not support read-only transactions")
+	} else {
+		tx, err = c.Conn.Begin()
+	}
+	c.metrics.observe(Begin, start, err)
+	if err != nil {
+		return nil, err
+	}
+	return &transaction{Tx: tx, metrics: c.metrics, start: start}, nil
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *conn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *conn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+type stmt struct {
+	driver.Stmt
+	conn    *conn
+	metrics *driverMetrics
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	var result driver.Result
+	var err error
+	if e, ok := s.Stmt.(driver.StmtExecContext); ok {
+		result, err = e.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			result, err = s.Stmt.Exec(values)
+		}
+	}
+	s.metrics.observe(Exec, start, err)
+	return result, err
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	var rows driver.Rows
+	var err error
+	if q, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = q.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			rows, err = s.Stmt.Query(values)
+		}
+	}
+	s.metrics.observe(Query, start, err)
+	return rows, err
+}
+
+// CheckNamedValue implements driver.NamedValueChecker with the checker of the wrapped
+// statement, or else of its connection, since database/sql only asks the connection
+// when the statement has no checker.
+func (s *stmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return s.conn.CheckNamedValue(nv)
+}
+
+// ColumnConverter implements driver.ColumnConverter with the converter of the wrapped
+// statement, or the default one of database/sql.
+func (s *stmt) ColumnConverter(idx int) driver.ValueConverter {
+	if cc, ok := s.Stmt.(driver.ColumnConverter); ok {
+		return cc.ColumnConverter(idx)
+	}
+	return driver.DefaultParameterConverter
+}
+
+type transaction struct {
+	driver.Tx
+	metrics *driverMetrics
+	start   time.Time
+}
+
+func (t *transaction) Commit() error {
+	start := time.Now()
+	err := t.Tx.Commit()
+	t.metrics.observe(Commit, start, err)
+	t.metrics.observe(Transaction, t.start, err)
+	return err
+}
+
+func (t *transaction) Rollback() error {
+	start := time.Now()
+	err := t.Tx.Rollback()
+	t.metrics.observe(Rollback, start, err)
+	t.metrics.observe(Transaction, t.start, err)
+	return err
+}
+
+// namedValuesToValues converts the arguments for the methods without context,
+// which do not support names, like database/sql does.
+func namedValuesToValues(named []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(named))
+	for i, nv := range named {
+		if nv.Name != "" {
+			return nil, errors.New("sql: driver does not support the use of Named Parameters")
+		}
+		values[i] = nv.Value
+	}
+	return values, nil
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+)
+
+func errorsMetric(op string, value int) metricstest.ExpectedMetric {
+	return metricstest.ExpectedMetric{Name: "sql.errors", Tags: map[string]string{"operation": op}, Value: value}
+}
+
+// open opens a database with a wrapped driver, like sql.Open after sql.Register.
+func open(t *testing.T, d driver.Driver, name string) *sql.DB {
+	connector, err := d.(driver.DriverContext).OpenConnector(name)
+	require.NoError(t, err)
+	return sql.OpenDB(connector)
+}
+
+func exercise(t *testing.T, db *sql.DB) {
+	_, err := db.Exec("insert")
+	require.NoError(t, err)
+	_, err = db.Exec("fail")
+	require.Error(t, err)
+	var n int
+	require.NoError(t, db.QueryRow("select").Scan(&n))
+	assert.Equal(t, 42, n)
+	_, err = db.Query("fail")
+	require.Error(t, err)
+	_, err = db.Prepare("unprepared")
+	require.Error(t, err)
+
+	stmt, err := db.Prepare("insert")
+	require.NoError(t, err)
+	_, err = stmt.Exec("positional", 1)
+	require.NoError(t, err)
+	require.NoError(t, stmt.QueryRow().Scan(&n))
+	require.NoError(t, stmt.Close())
+
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	_, err = tx.Exec("insert")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	tx, err = db.Begin()
+	require.NoError(t, err)
+	require.Error(t, tx.Rollback())
+}
+
+func TestWrapDriver(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	db := open(t, WrapDriver(&fakeDriver{withContext: true}, f), "")
+	defer db.Close()
+
+	exercise(t, db)
+	assert.EqualError(t, db.Ping(), "unreachable")
+	f.AssertCounterMetrics(t,
+		errorsMetric(Exec, 1),
+		errorsMetric(Query, 1),
+		errorsMetric(Prepare, 1),
+		errorsMetric(Begin, 0),
+		errorsMetric(Commit, 0),
+		errorsMetric(Rollback, 1),
+		errorsMetric(Transaction, 1),
+	)
+	_, gauges := f.Snapshot()
+	for _, op := range operations {
+		assert.Contains(t, gauges, "sql.latency|operation="+op+".P99")
+	}
+}
+
+func TestWrapDriverFallbacks(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	db := open(t, WrapDriver(&fakeDriver{}, f), "")
+	defer db.Close()
+
+	exercise(t, db)
+	require.NoError(t, db.Ping())
+	_, err := db.BeginTx(context.Background(), &sql.TxOptions{ReadOnly: true})
+	assert.EqualError(t, err, "sql: driver does not support read-only transactions")
+	_, err = db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	assert.EqualError(t, err, "sql: driver does not support non-default isolation level")
+
+	// the queries and execs are prepared by database/sql, and recorded once
+	f.AssertCounterMetrics(t,
+		errorsMetric(Exec, 1),
+		errorsMetric(Query, 1),
+		errorsMetric(Prepare, 1),
+		errorsMetric(Begin, 2),
+		errorsMetric(Rollback, 1),
+	)
+
+	bad := open(t, WrapDriver(&fakeDriver{}, f), "fail")
+	assert.EqualError(t, bad.Ping(), "cannot connect")
+}
+
+func TestWrapDriverConnChecker(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	db := open(t, WrapDriver(&fakeDriver{withChecker: true}, f), "")
+	defer db.Close()
+
+	stmt, err := db.Prepare("insert")
+	require.NoError(t, err)
+	defer stmt.Close()
+	_, err = stmt.Exec(point{x: 1, y: 2}, 3)
+	require.NoError(t, err, "the values are checked by the connection")
+	_, err = stmt.Exec(struct{}{})
+	assert.Error(t, err, "the other values are checked by database/sql")
+}
+
+func TestWrapConnector(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	db := sql.OpenDB(WrapConnector(&fakeConnector{driver: &fakeDriver{}}, f))
+	defer db.Close()
+	_, err := db.Exec("fail")
+	require.Error(t, err)
+	f.AssertCounterMetrics(t, errorsMetric(Exec, 1))
+	assert.IsType(t, &wrappedDriver{}, db.Driver())
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+// DefaultInterval is how often the statistics of the pool are read.
+const DefaultInterval = 10 * time.Second
+
+type options struct {
+	interval time.Duration
+}
+
+// Option is a function that sets some option for the StatsCollector constructor.
+type Option func(*options)
+
+// WithInterval returns an option that sets how often the statistics of the pool are read.
+// If not used, we fallback to DefaultInterval. A non-positive interval disables
+// the periodic reads, Collect must be called instead.
+func WithInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.interval = interval
+	}
+}
+
+// StatsCollector periodically reads the sql.DBStats of a connection pool and publishes
+// them in the namespace "sql" of a metrics.Factory:
+//
+//   - Gauges "max_open_connections", "open_connections", "in_use_connections"
+//     and "idle_connections".
+//   - Counters "wait_count" and "wait_duration_ms" of the waits for a connection.
+//   - Counters "max_idle_closed", "max_idle_time_closed" and "max_lifetime_closed"
+//     of the connections closed by the pool.
+//
+// A factory tagged with the name of the database tells apart several pools.
+type StatsCollector struct {
+	db       *sql.DB
+	gauges   []statsGauge
+	counters []statsCounter
+	lock     sync.Mutex
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+type statsGauge struct {
+	value func(*sql.DBStats) int64
+	gauge metrics.Gauge
+}
+
+type statsCounter struct {
+	value   func(*sql.DBStats) int64
+	counter metrics.Counter
+	last    int64
+}
+
+// NewStatsCollector creates a StatsCollector of db publishing into factory, and starts
+// reading the statistics on the interval of WithInterval.
+func NewStatsCollector(db *sql.DB, factory metrics.Factory, opts ...Option) *StatsCollector {
+	options := &options{interval: DefaultInterval}
+	for _, o := range opts {
+		o(options)
+	}
+	ns := factory.Namespace(metrics.NSOptions{Name: "sql"})
+	gauge := func(name string, value func(*sql.DBStats) int64) statsGauge {
+		return statsGauge{value: value, gauge: ns.Gauge(metrics.Options{Name: name})}
+	}
+	counter := func(name string, value func(*sql.DBStats) int64) statsCounter {
+		return statsCounter{value: value, counter: ns.Counter(metrics.Options{Name: name})}
+	}
+	c := &StatsCollector{
+		db: db,
+		gauges: []statsGauge{
+			gauge("max_open_connections", func(s *sql.DBStats) int64 { return int64(s.MaxOpenConnections) }),
+			gauge("open_connections", func(s *sql.DBStats) int64 { return int64(s.OpenConnections) }),
+			gauge("in_use_connections", func(s *sql.DBStats) int64 { return int64(s.InUse) }),
+			gauge("idle_connections", func(s *sql.DBStats) int64 { return int64(s.Idle) }),
+		},
+		counters: []statsCounter{
+			counter("wait_count", func(s *sql.DBStats) int64 { return s.WaitCount }),
+			counter("wait_duration_ms", func(s *sql.DBStats) int64 { return int64(s.WaitDuration / time.Millisecond) }),
+			counter("max_idle_closed", func(s *sql.DBStats) int64 { return s.MaxIdleClosed }),
+			counter("max_idle_time_closed", func(s *sql.DBStats) int64 { return s.MaxIdleTimeClosed }),
+			counter("max_lifetime_closed", func(s *sql.DBStats) int64 { return s.MaxLifetimeClosed }),
+		},
+		stop: make(chan struct{}),
+	}
+	if options.interval > 0 {
+		c.wg.Add(1)
+		go c.loop(options.interval)
+	}
+	return c
+}
+
+func (c *StatsCollector) loop(interval time.Duration) {
+	defer c.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		c.Collect()
+		select {
+		case <-ticker.C:
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+// Stop stops the periodic reads.
+func (c *StatsCollector) Stop() {
+	c.once.Do(func() {
+		close(c.stop)
+		c.wg.Wait()
+	})
+}
+
+// Collect reads the statistics of the pool and updates the metrics.
+func (c *StatsCollector) Collect() {
+	stats := c.db.Stats()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, g := range c.gauges {
+		g.gauge.Update(g.value(&stats))
+	}
+	for i := range c.counters {
+		counter := &c.counters[i]
+		if value := counter.value(&stats); value > counter.last {
+			counter.counter.Inc(value - counter.last)
+			counter.last = value
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+)
+
+func init() {
+	sql.Register("fake", &fakeDriver{})
+}
+
+func TestStatsCollector(t *testing.T) {
+	db, err := sql.Open("fake", "")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	c := NewStatsCollector(db, f, WithInterval(0))
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	c.Collect()
+	f.AssertGaugeMetrics(t,
+		metricstest.ExpectedMetric{Name: "sql.max_open_connections", Value: 1},
+		metricstest.ExpectedMetric{Name: "sql.open_connections", Value: 1},
+		metricstest.ExpectedMetric{Name: "sql.in_use_connections", Value: 1},
+		metricstest.ExpectedMetric{Name: "sql.idle_connections", Value: 0},
+	)
+	f.AssertCounterMetrics(t, metricstest.ExpectedMetric{Name: "sql.wait_count", Value: 0})
+
+	// a second connection waits for the first one
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn, err := db.Conn(ctx)
+		if err == nil {
+			conn.Close()
+		}
+	}()
+	for db.Stats().WaitCount == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(2 * time.Millisecond)
+	require.NoError(t, conn.Close())
+	<-done
+	c.Collect()
+	c.Collect()
+	f.AssertGaugeMetrics(t,
+		metricstest.ExpectedMetric{Name: "sql.in_use_connections", Value: 0},
+		metricstest.ExpectedMetric{Name: "sql.idle_connections", Value: 1},
+	)
+	f.AssertCounterMetrics(t, metricstest.ExpectedMetric{Name: "sql.wait_count", Value: 1})
+	counters, _ := f.Snapshot()
+	require.True(t, counters["sql.wait_duration_ms"] >= 2)
+}
+
+func TestStatsCollectorLoop(t *testing.T) {
+	db, err := sql.Open("fake", "")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(3)
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	c := NewStatsCollector(db, f, WithInterval(time.Millisecond))
+	for i := 0; i < 1000; i++ {
+		if _, gauges := f.Snapshot(); gauges["sql.max_open_connections"] == 3 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	c.Stop()
+	c.Stop()
+	f.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "sql.max_open_connections", Value: 3})
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsd
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/adapters"
+)
+
+// Factory implements metrics.Factory by sending StatsD lines over UDP or a Unix socket.
+// StatsD has no notion of tags, so tags are flattened into the metric name
+// the same way as adapters.WrapFactoryWithoutTags does, e.g. "requests.result_ok".
+type Factory struct {
+	metrics.Factory
+	sender *Sender
+}
+
+type options struct {
+	prefix        string
+	maxPacketSize int
+	flushInterval time.Duration
+	adapters      adapters.Options
+	errorHandler  func(error)
+}
+
+// Option is a function that sets some option for the Factory constructor.
+type Option func(*options)
+
+// WithPrefix returns an option that sets a prefix prepended to all metric names,
+// e.g. "jaeger.". The prefix is used verbatim, without a separator.
+func WithPrefix(prefix string) Option {
+	return func(opts *options) {
+		opts.prefix = prefix
+	}
+}
+
+// WithMaxPacketSize returns an option that sets the maximum size of a packet.
+// If not used, we fallback to DefaultUDPPacketSize for UDP and to
+// DefaultUnixPacketSize for Unix sockets.
+func WithMaxPacketSize(size int) Option {
+	return func(opts *options) {
+		opts.maxPacketSize = size
+	}
+}
+
+// WithFlushInterval returns an option that sets how often buffered lines are sent
+// when the packet is not full. If not used, we fallback to DefaultFlushInterval.
+func WithFlushInterval(interval time.Duration) Option {
+	return func(opts *options) {
+		opts.flushInterval = interval
+	}
+}
+
+// WithAdaptersOptions returns an option that sets the separators used to build
+// metric names from namespaces and tags.
+// If not used, we fallback to the defaults of adapters.Options.
+func WithAdaptersOptions(adaptersOptions adapters.Options) Option {
+	return func(opts *options) {
+		opts.adapters = adaptersOptions
+	}
+}
+
+// WithErrorHandler returns an option that sets a function called with errors
+// writing packets. If not used, such errors are dropped.
+func WithErrorHandler(handler func(error)) Option {
+	return func(opts *options) {
+		opts.errorHandler = handler
+	}
+}
+
+func applyOptions(network string, opts []Option) *options {
+	options := &options{
+		flushInterval: DefaultFlushInterval,
+	}
+	for _, o := range opts {
+		o(options)
+	}
+	if options.maxPacketSize <= 0 {
+		options.maxPacketSize = DefaultPacketSize(network)
+	}
+	return options
+}
+
+// DefaultPacketSize returns the default maximum packet size for the network,
+// as accepted by net.Dial.
+func DefaultPacketSize(network string) int {
+	if strings.HasPrefix(network, "unix") {
+		return DefaultUnixPacketSize
+	}
+	return DefaultUDPPacketSize
+}
+
+// New creates a Factory sending metrics to a StatsD server at address, where network
+// is "udp", "udp4", "udp6" or "unixgram", as accepted by net.Dial.
+// Close must be called to send the buffered metrics and release the connection.
+func New(network, address string, opts ...Option) (*Factory, error) {
+	conn, err := net.Dial(network, address)
+	if err != nil {
+		return nil, err
+	}
+	options := applyOptions(network, opts)
+	sender := NewSender(conn, options.maxPacketSize, options.flushInterval, options.errorHandler)
+	return &Factory{
+		Factory: adapters.WrapFactoryWithoutTags(
+			&factory{
+				prefix: options.prefix,
+				sender: sender,
+			},
+			options.adapters,
+		),
+		sender: sender,
+	}, nil
+}
+
+// Flush sends the buffered metrics.
+func (f *Factory) Flush() error {
+	return f.sender.Flush()
+}
+
+// Close sends the buffered metrics and closes the connection.
+func (f *Factory) Close() error {
+	return f.sender.Close()
+}
+
+// factory implements adapters.FactoryWithoutTags
+type factory struct {
+	prefix string
+	sender *Sender
+}
+
+func (f *factory) name(name string) string {
+	return Sanitize(f.prefix + name)
+}
+
+func (f *factory) Counter(options adapters.TaglessOptions) metrics.Counter {
+	return &counter{metric{name: f.name(options.Name), sender: f.sender}}
+}
+
+func (f *factory) Gauge(options adapters.TaglessOptions) metrics.Gauge {
+	return &gauge{metric{name: f.name(options.Name), sender: f.sender}}
+}
+
+func (f *factory) Timer(options adapters.TaglessTimerOptions) metrics.Timer {
+	// buckets are configured on the StatsD server
+	return &timer{metric{name: f.name(options.Name), sender: f.sender}}
+}
+
+func (f *factory) Histogram(options adapters.TaglessHistogramOptions) metrics.Histogram {
+	// buckets are configured on the StatsD server
+	return &histogram{metric{name: f.name(options.Name), sender: f.sender}}
+}
+
+// sanitizer replaces the characters that have a meaning in the StatsD line protocol.
+var sanitizer = strings.NewReplacer(":", "_", "|", "_", "@", "_", "\n", "_", " ", "_")
+
+// Sanitize replaces the characters that cannot be used in a StatsD metric name.
+func Sanitize(name string) string {
+	return sanitizer.Replace(name)
+}
+
+type metric struct {
+	name   string
+	sender *Sender
+}
+
+func (m *metric) send(value string, metricType string) {
+	line := make([]byte, 0, len(m.name)+len(value)+len(metricType)+2)
+	line = append(line, m.name...)
+	line = append(line, ':')
+	line = append(line, value...)
+	line = append(line, '|')
+	line = append(line, metricType...)
+	m.sender.Send(line)
+}
+
+type counter struct {
+	metric
+}
+
+func (c *counter) Inc(delta int64) {
+	c.send(strconv.FormatInt(delta, 10), "c")
+}
+
+type gauge struct {
+	metric
+}
+
+func (g *gauge) Update(value int64) {
+	if value < 0 {
+		// a signed value would be interpreted as a relative change of the gauge
+		g.send("0", "g")
+	}
+	g.send(strconv.FormatInt(value, 10), "g")
+}
+
+type timer struct {
+	metric
+}
+
+func (t *timer) Record(d time.Duration) {
+	t.send(FormatMillis(d), "ms")
+}
+
+type histogram struct {
+	metric
+}
+
+func (h *histogram) Record(value float64) {
+	h.send(strconv.FormatFloat(value, 'f', -1, 64), "h")
+}
+
+// FormatMillis formats a duration as a number of milliseconds,
+// the unit StatsD expects for timers.
+func FormatMillis(d time.Duration) string {
+	return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', -1, 64)
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsd
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/jaeger-lib/metrics"
+	"github.com/uber/jaeger-lib/metrics/adapters"
+)
+
+var _ metrics.Factory = new(Factory)
+
+func listenUDP(t *testing.T) net.PacketConn {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	return conn
+}
+
+func readPackets(t *testing.T, conn net.PacketConn, n int) []string {
+	var packets []string
+	buf := make([]byte, 65536)
+	for i := 0; i < n; i++ {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		size, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		packets = append(packets, string(buf[:size]))
+	}
+	return packets
+}
+
+func TestFactory(t *testing.T) {
+	conn := listenUDP(t)
+	defer conn.Close()
+
+	f, err := New("udp", conn.LocalAddr().String(), WithPrefix("jaeger."), WithFlushInterval(0))
+	require.NoError(t, err)
+
+	ns := f.Namespace(metrics.NSOptions{
+		Name: "query",
+		Tags: map[string]string{"a": "b"},
+	})
+	ns.Counter(metrics.Options{
+		Name: "requests",
+		Tags: map[string]string{"result": "ok"},
+	}).Inc(3)
+	ns.Gauge(metrics.Options{Name: "queue-length"}).Update(42)
+	ns.Gauge(metrics.Options{Name: "delta"}).Update(-5)
+	ns.Timer(metrics.TimerOptions{Name: "latency"}).Record(1500 * time.Microsecond)
+	ns.Histogram(metrics.HistogramOptions{Name: "size"}).Record(1.5)
+	require.NoError(t, f.Close())
+
+	packets := readPackets(t, conn, 1)
+	assert.Equal(t, strings.Join([]string{
+		"jaeger.query.requests.a_b.result_ok:3|c",
+		"jaeger.query.queue-length.a_b:42|g",
+		"jaeger.query.delta.a_b:0|g",
+		"jaeger.query.delta.a_b:-5|g",
+		"jaeger.query.latency.a_b:1.5|ms",
+		"jaeger.query.size.a_b:1.5|h",
+	}, "\n"), packets[0])
+}
+
+func TestFactoryMaxPacketSize(t *testing.T) {
+	conn := listenUDP(t)
+	defer conn.Close()
+
+	f, err := New("udp", conn.LocalAddr().String(), WithMaxPacketSize(40), WithFlushInterval(0))
+	require.NoError(t, err)
+	c := f.Counter(metrics.Options{Name: "counter"})
+	for i := 0; i < 10; i++ {
+		c.Inc(1) // 11 bytes per line
+	}
+	require.NoError(t, f.Close())
+
+	packets := readPackets(t, conn, 4)
+	lines := 0
+	for _, p := range packets {
+		assert.True(t, len(p) <= 40, "packet of %d bytes", len(p))
+		lines += len(strings.Split(p, "\n"))
+	}
+	assert.Equal(t, 10, lines)
+	assert.Equal(t, "counter:1|c\ncounter:1|c\ncounter:1|c", packets[0])
+}
+
+func TestFactoryFlushInterval(t *testing.T) {
+	conn := listenUDP(t)
+	defer conn.Close()
+
+	f, err := New("udp", conn.LocalAddr().String(), WithFlushInterval(time.Millisecond))
+	require.NoError(t, err)
+	defer f.Close()
+	f.Gauge(metrics.Options{Name: "gauge"}).Update(1)
+
+	assert.Equal(t, []string{"gauge:1|g"}, readPackets(t, conn, 1))
+}
+
+func TestFactoryAdaptersOptions(t *testing.T) {
+	conn := listenUDP(t)
+	defer conn.Close()
+
+	f, err := New("udp", conn.LocalAddr().String(),
+		WithFlushInterval(0),
+		WithAdaptersOptions(adapters.Options{ScopeSep: "_", TagsSep: ".", TagKVSep: "-"}),
+	)
+	require.NoError(t, err)
+	f.Namespace(metrics.NSOptions{Name: "ns"}).Counter(metrics.Options{
+		Name: "counter",
+		Tags: map[string]string{"x": "y"},
+	}).Inc(1)
+	require.NoError(t, f.Flush())
+
+	assert.Equal(t, []string{"ns_counter.x-y:1|c"}, readPackets(t, conn, 1))
+	require.NoError(t, f.Close())
+}
+
+func TestFactorySanitize(t *testing.T) {
+	conn := listenUDP(t)
+	defer conn.Close()
+
+	f, err := New("udp", conn.LocalAddr().String(), WithFlushInterval(0))
+	require.NoError(t, err)
+	f.Counter(metrics.Options{
+		Name: "counter",
+		Tags: map[string]string{"url": "http://host|1@x y"},
+	}).Inc(1)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, []string{"counter.url_http_//host_1_x_y:1|c"}, readPackets(t, conn, 1))
+}
+
+func TestFactoryUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "statsd")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "statsd.sock")
+	conn, err := net.ListenPacket("unixgram", path)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	f, err := New("unixgram", path, WithFlushInterval(0))
+	require.NoError(t, err)
+	f.Counter(metrics.Options{Name: "counter"}).Inc(1)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, []string{"counter:1|c"}, readPackets(t, conn, 1))
+}
+
+func TestFactoryDialError(t *testing.T) {
+	_, err := New("invalid", "localhost:8125")
+	assert.Error(t, err)
+}
+
+func TestDefaultPacketSize(t *testing.T) {
+	assert.Equal(t, DefaultUDPPacketSize, DefaultPacketSize("udp"))
+	assert.Equal(t, DefaultUnixPacketSize, DefaultPacketSize("unixgram"))
+}
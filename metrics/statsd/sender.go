@@ -0,0 +1,150 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsd
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultUDPPacketSize fits a datagram into a 1500 bytes Ethernet MTU
+	// once the IP and UDP headers are accounted for.
+	DefaultUDPPacketSize = 1432
+
+	// DefaultUnixPacketSize is used for Unix domain sockets, which are not limited by the MTU.
+	DefaultUnixPacketSize = 8192
+
+	// DefaultFlushInterval is how often buffered lines are sent when the packet is not full.
+	DefaultFlushInterval = time.Second
+)
+
+// Sender packs newline-separated StatsD lines into packets of up to a maximum size,
+// and writes a packet when the next line does not fit or when the flush interval elapses.
+// It is safe for concurrent use.
+type Sender struct {
+	lock          sync.Mutex
+	writer        io.Writer
+	buf           []byte
+	maxPacketSize int
+	errorHandler  func(error)
+	stop          chan struct{}
+	wg            sync.WaitGroup
+	once          sync.Once
+}
+
+// NewSender creates a Sender writing packets to the writer, typically a connection
+// returned by net.Dial. If flushInterval is positive, a background goroutine flushes
+// the buffered lines on that interval until Close is called. Write errors are passed
+// to errorHandler, which may be nil.
+func NewSender(writer io.Writer, maxPacketSize int, flushInterval time.Duration, errorHandler func(error)) *Sender {
+	if errorHandler == nil {
+		errorHandler = func(error) {}
+	}
+	s := &Sender{
+		writer:        writer,
+		buf:           make([]byte, 0, maxPacketSize),
+		maxPacketSize: maxPacketSize,
+		errorHandler:  errorHandler,
+		stop:          make(chan struct{}),
+	}
+	if flushInterval > 0 {
+		s.wg.Add(1)
+		go s.flushLoop(flushInterval)
+	}
+	return s
+}
+
+func (s *Sender) flushLoop(flushInterval time.Duration) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Flush(); err != nil {
+				s.errorHandler(err)
+			}
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// Send buffers a single line, without the trailing newline. If the line does not fit
+// into the current packet, the packet is written first. Lines longer than the
+// maximum packet size are written in a packet of their own.
+// The write errors are passed to the error handler once the Sender is unlocked,
+// so that the handler can send metrics through it.
+func (s *Sender) Send(line []byte) {
+	s.lock.Lock()
+	var errs []error
+	size := len(line)
+	if len(s.buf) > 0 {
+		size++ // newline separator
+	}
+	if len(s.buf)+size > s.maxPacketSize && len(s.buf) > 0 {
+		if err := s.flush(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(s.buf) > 0 {
+		s.buf = append(s.buf, '\n')
+	}
+	s.buf = append(s.buf, line...)
+	if len(s.buf) >= s.maxPacketSize {
+		if err := s.flush(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	s.lock.Unlock()
+	for _, err := range errs {
+		s.errorHandler(err)
+	}
+}
+
+// Flush writes the buffered lines, if any.
+func (s *Sender) Flush() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.flush()
+}
+
+func (s *Sender) flush() error {
+	if len(s.buf) == 0 {
+		return nil
+	}
+	_, err := s.writer.Write(s.buf)
+	s.buf = s.buf[:0]
+	return err
+}
+
+// Close stops the background flushing, writes the buffered lines and closes
+// the writer if it implements io.Closer.
+func (s *Sender) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.stop)
+		s.wg.Wait()
+		err = s.Flush()
+		if closer, ok := s.writer.(io.Closer); ok {
+			if cerr := closer.Close(); err == nil {
+				err = cerr
+			}
+		}
+	})
+	return err
+}
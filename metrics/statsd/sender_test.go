@@ -0,0 +1,130 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statsd
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type packetWriter struct {
+	sync.Mutex
+	packets []string
+	err     error
+	closed  bool
+}
+
+func (w *packetWriter) Write(p []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+	w.packets = append(w.packets, string(p))
+	return len(p), w.err
+}
+
+func (w *packetWriter) Close() error {
+	w.Lock()
+	defer w.Unlock()
+	w.closed = true
+	return nil
+}
+
+func (w *packetWriter) snapshot() []string {
+	w.Lock()
+	defer w.Unlock()
+	return append([]string(nil), w.packets...)
+}
+
+func TestSenderPacking(t *testing.T) {
+	w := &packetWriter{}
+	s := NewSender(w, 20, 0, nil)
+	s.Send([]byte("aaaa:1|c"))                       // 8 bytes
+	s.Send([]byte("bbbb:1|c"))                       // 17 bytes with separator
+	s.Send([]byte("cccc:1|c"))                       // does not fit, the first packet is written
+	s.Send([]byte("dddddddddddddddddddddddddd:1|c")) // larger than a packet
+	s.Send([]byte("eeee:1|c"))
+	assert.Equal(t, []string{
+		"aaaa:1|c\nbbbb:1|c",
+		"cccc:1|c",
+		"dddddddddddddddddddddddddd:1|c",
+	}, w.snapshot())
+
+	require.NoError(t, s.Close())
+	assert.Equal(t, "eeee:1|c", w.snapshot()[3])
+	assert.True(t, w.closed)
+	require.NoError(t, s.Close(), "second Close is a no-op")
+	assert.Len(t, w.snapshot(), 4)
+}
+
+func TestSenderExactlyFull(t *testing.T) {
+	w := &packetWriter{}
+	s := NewSender(w, 17, 0, nil)
+	s.Send([]byte("aaaa:1|c"))
+	s.Send([]byte("bbbb:1|c"))
+	assert.Equal(t, []string{"aaaa:1|c\nbbbb:1|c"}, w.snapshot())
+	require.NoError(t, s.Flush())
+	assert.Len(t, w.snapshot(), 1, "nothing to flush")
+}
+
+func TestSenderFlushInterval(t *testing.T) {
+	w := &packetWriter{}
+	s := NewSender(w, DefaultUDPPacketSize, time.Millisecond, nil)
+	defer s.Close()
+	s.Send([]byte("aaaa:1|c"))
+	for i := 0; i < 1000 && len(w.snapshot()) == 0; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, []string{"aaaa:1|c"}, w.snapshot())
+}
+
+func TestSenderErrors(t *testing.T) {
+	w := &packetWriter{err: errors.New("write failed")}
+	var errs []error
+	s := NewSender(w, 10, 0, func(err error) { errs = append(errs, err) })
+	s.Send([]byte("aaaa:1|c"))
+	s.Send([]byte("bbbb:1|c"))
+	assert.Len(t, errs, 1)
+	assert.Error(t, s.Flush())
+	assert.NoError(t, s.Close())
+}
+
+func TestSenderErrorHandlerSends(t *testing.T) {
+	w := &packetWriter{err: errors.New("write failed")}
+	var s *Sender
+	handled := 0
+	s = NewSender(w, 10, 0, func(err error) {
+		handled++
+		if handled == 1 {
+			// e.g. counting the errors with a metric of the same Sender
+			s.Send([]byte("errors:1|c"))
+		}
+	})
+	s.Send([]byte("aaaa:1|c"))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Send([]byte("bbbb:1|c"))
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the error handler deadlocked the Sender")
+	}
+	assert.True(t, handled >= 1)
+}
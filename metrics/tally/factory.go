@@ -20,16 +20,80 @@ import (
 	"github.com/uber/jaeger-lib/metrics"
 )
 
+// DefaultBuckets are the buckets of Histograms declared without buckets,
+// unless set with WithDefaultBuckets.
+var DefaultBuckets = tally.ValueBuckets{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// TimerKind controls which tally metric the Timers are reported with.
+type TimerKind int
+
+const (
+	// TimersByBuckets reports the Timers declared with buckets as tally Histograms
+	// with these duration buckets, and the other Timers as tally Timers.
+	TimersByBuckets TimerKind = iota
+	// TimersAsTimers reports all the Timers as tally Timers, ignoring their buckets.
+	TimersAsTimers
+	// TimersAsHistograms reports all the Timers as tally Histograms with duration buckets,
+	// the default timer buckets when they are declared without buckets.
+	TimersAsHistograms
+)
+
+type options struct {
+	timerKind           TimerKind
+	defaultBuckets      tally.Buckets
+	defaultTimerBuckets tally.Buckets
+}
+
+// Option is a function that sets some option for Wrap.
+type Option func(*options)
+
+// WithTimerKind returns an option that sets which tally metric the Timers are
+// reported with. If not used, we fallback to TimersByBuckets.
+func WithTimerKind(kind TimerKind) Option {
+	return func(opts *options) {
+		opts.timerKind = kind
+	}
+}
+
+// WithDefaultBuckets returns an option that sets the buckets of the Histograms
+// declared without buckets, either tally.ValueBuckets or tally.DurationBuckets.
+// If not used, we fallback to DefaultBuckets.
+func WithDefaultBuckets(buckets tally.Buckets) Option {
+	return func(opts *options) {
+		if buckets != nil && buckets.Len() > 0 {
+			opts.defaultBuckets = buckets
+		}
+	}
+}
+
+// WithDefaultTimerBuckets returns an option that sets the buckets of the Timers
+// declared without buckets and reported as tally Histograms with TimersAsHistograms.
+// If not used, we fallback to the default buckets of the tally Scope.
+func WithDefaultTimerBuckets(buckets tally.DurationBuckets) Option {
+	return func(opts *options) {
+		if len(buckets) > 0 {
+			opts.defaultTimerBuckets = buckets
+		}
+	}
+}
+
 // Wrap takes a tally Scope and returns jaeger-lib metrics.Factory.
-func Wrap(scope tally.Scope) metrics.Factory {
+// tally has no descriptions, so the Help of the options is ignored.
+func Wrap(scope tally.Scope, opts ...Option) metrics.Factory {
+	options := &options{defaultBuckets: DefaultBuckets}
+	for _, o := range opts {
+		o(options)
+	}
 	return &factory{
-		tally: scope,
+		tally:   scope,
+		options: options,
 	}
 }
 
 // TODO implement support for tags if tally.Scope does not support them
 type factory struct {
-	tally tally.Scope
+	tally   tally.Scope
+	options *options
 }
 
 func (f *factory) Counter(options metrics.Options) metrics.Counter {
@@ -53,7 +117,12 @@ func (f *factory) Timer(options metrics.TimerOptions) metrics.Timer {
 	if len(options.Tags) > 0 {
 		scope = scope.Tagged(options.Tags)
 	}
-	// TODO: Determine whether buckets can be used
+	switch {
+	case f.options.timerKind == TimersAsHistograms && len(options.Buckets) == 0:
+		return NewHistogramTimer(scope.Histogram(options.Name, f.options.defaultTimerBuckets))
+	case f.options.timerKind != TimersAsTimers && len(options.Buckets) > 0:
+		return NewHistogramTimer(scope.Histogram(options.Name, tally.DurationBuckets(options.Buckets)))
+	}
 	return NewTimer(scope.Timer(options.Name))
 }
 
@@ -62,11 +131,16 @@ func (f *factory) Histogram(options metrics.HistogramOptions) metrics.Histogram
 	if len(options.Tags) > 0 {
 		scope = scope.Tagged(options.Tags)
 	}
-	return NewHistogram(scope.Histogram(options.Name, tally.ValueBuckets(options.Buckets)))
+	buckets := f.options.defaultBuckets
+	if len(options.Buckets) > 0 {
+		buckets = tally.ValueBuckets(options.Buckets)
+	}
+	return NewHistogram(scope.Histogram(options.Name, buckets))
 }
 
 func (f *factory) Namespace(scope metrics.NSOptions) metrics.Factory {
 	return &factory{
-		tally: f.tally.SubScope(scope.Name).Tagged(scope.Tags),
+		tally:   f.tally.SubScope(scope.Name).Tagged(scope.Tags),
+		options: f.options,
 	}
 }
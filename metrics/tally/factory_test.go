@@ -1,10 +1,12 @@
 package tally
 
 import (
+	"math"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/uber/jaeger-lib/metrics"
 
 	"github.com/uber-go/tally"
@@ -74,3 +76,74 @@ func TestFactory(t *testing.T) {
 	assert.Equal(t, int64(0), hs.Values()[200])
 	assert.EqualValues(t, expectedTags, hs.Tags())
 }
+
+// histogram returns the tally histogram with the given name from the snapshot
+// of a test scope without tags.
+func histogram(t *testing.T, snapshot tally.Snapshot, name string) tally.HistogramSnapshot {
+	h := snapshot.Histograms()[name+"+"]
+	if h == nil {
+		// tally v3 includes tags in the name.
+		h = snapshot.Histograms()[name]
+	}
+	require.NotNil(t, h, name)
+	return h
+}
+
+func TestTimerBuckets(t *testing.T) {
+	testScope := tally.NewTestScope("", nil)
+	factory := Wrap(testScope)
+	factory.Timer(metrics.TimerOptions{
+		Name:    "bucketed",
+		Buckets: []time.Duration{10 * time.Millisecond, time.Second},
+	}).Record(42 * time.Millisecond)
+	factory.Timer(metrics.TimerOptions{Name: "plain"}).Record(42 * time.Millisecond)
+
+	snapshot := testScope.Snapshot()
+	h := histogram(t, snapshot, "bucketed")
+	assert.Equal(t, map[time.Duration]int64{
+		10 * time.Millisecond: 0,
+		time.Second:           1,
+		math.MaxInt64:         0,
+	}, h.Durations())
+	assert.Len(t, snapshot.Timers(), 1)
+}
+
+func TestTimerKind(t *testing.T) {
+	testScope := tally.NewTestScope("", nil)
+	factory := Wrap(testScope, WithTimerKind(TimersAsTimers))
+	factory.Timer(metrics.TimerOptions{
+		Name:    "timer",
+		Buckets: []time.Duration{time.Second},
+	}).Record(time.Millisecond)
+	snapshot := testScope.Snapshot()
+	assert.Len(t, snapshot.Timers(), 1)
+	assert.Empty(t, snapshot.Histograms())
+
+	testScope = tally.NewTestScope("", nil)
+	factory = Wrap(testScope,
+		WithTimerKind(TimersAsHistograms),
+		WithDefaultTimerBuckets(tally.DurationBuckets{time.Millisecond, time.Second}),
+	).Namespace(metrics.NSOptions{Name: "ns"})
+	factory.Timer(metrics.TimerOptions{Name: "timer"}).Record(500 * time.Microsecond)
+	snapshot = testScope.Snapshot()
+	assert.Empty(t, snapshot.Timers())
+	h := histogram(t, snapshot, "ns.timer")
+	assert.EqualValues(t, 1, h.Durations()[time.Millisecond], "the options are kept by namespaces")
+	assert.Len(t, h.Durations(), 3)
+}
+
+func TestDefaultBuckets(t *testing.T) {
+	testScope := tally.NewTestScope("", nil)
+	Wrap(testScope).Histogram(metrics.HistogramOptions{Name: "package-default"}).Record(1)
+	h := histogram(t, testScope.Snapshot(), "package-default")
+	assert.Empty(t, h.Durations(), "the default buckets are values, not durations")
+	assert.Len(t, h.Values(), len(DefaultBuckets)+1)
+	assert.EqualValues(t, 1, h.Values()[1])
+
+	factory := Wrap(testScope, WithDefaultBuckets(tally.ValueBuckets{1, 10}))
+	factory.Histogram(metrics.HistogramOptions{Name: "default"}).Record(5)
+	factory.Histogram(metrics.HistogramOptions{Name: "declared", Buckets: []float64{100}}).Record(5)
+	snapshot := testScope.Snapshot()
+	assert.Equal(t, map[float64]int64{1: 0, 10: 1, math.MaxFloat64: 0}, histogram(t, snapshot, "default").Values())
+	assert.Equal(t, map[float64]int64{100: 1, math.MaxFloat64: 0}, histogram(t, snapshot, "declared").Values())
+}
@@ -65,6 +65,22 @@ func (t *Timer) Record(delta time.Duration) {
 	t.timer.Record(delta)
 }
 
+// HistogramTimer is an adapter from go-tally Histogram to jaeger-lib Timer,
+// for histograms with duration buckets
+type HistogramTimer struct {
+	histogram tally.Histogram
+}
+
+// NewHistogramTimer creates a new HistogramTimer
+func NewHistogramTimer(histogram tally.Histogram) *HistogramTimer {
+	return &HistogramTimer{histogram: histogram}
+}
+
+// Record saves the time passed in.
+func (t *HistogramTimer) Record(delta time.Duration) {
+	t.histogram.RecordDuration(delta)
+}
+
 // Histogram is an adapter from go-tally Histogram to jaeger-lib Histogram
 type Histogram struct {
 	histogram tally.Histogram
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tally
+
+import (
+	"time"
+
+	"github.com/uber-go/tally"
+
+	"github.com/uber/jaeger-lib/metrics"
+)
+
+// NewScope takes a jaeger-lib metrics.Factory and returns a tally.Scope reporting into it,
+// the reverse of Wrap, so that code instrumented with tally can report into any Factory.
+//
+// SubScope and Tagged map to namespaces of the Factory, which joins the names with its
+// own separator. Gauges are truncated to integers. Histograms with tally.DurationBuckets
+// map to Timers with these buckets and record values as seconds, the other Histograms
+// map to Histograms, with the default buckets of the Factory for tally.DefaultBuckets,
+// and record durations as seconds.
+func NewScope(factory metrics.Factory) tally.Scope {
+	return &scope{factory: factory}
+}
+
+type scope struct {
+	factory metrics.Factory
+}
+
+func (s *scope) Counter(name string) tally.Counter {
+	return s.factory.Counter(metrics.Options{Name: name})
+}
+
+func (s *scope) Gauge(name string) tally.Gauge {
+	return &scopeGauge{gauge: s.factory.Gauge(metrics.Options{Name: name})}
+}
+
+func (s *scope) Timer(name string) tally.Timer {
+	return &scopeTimer{timer: s.factory.Timer(metrics.TimerOptions{Name: name})}
+}
+
+func (s *scope) Histogram(name string, buckets tally.Buckets) tally.Histogram {
+	if durations, ok := buckets.(tally.DurationBuckets); ok {
+		return &scopeHistogram{timer: s.factory.Timer(metrics.TimerOptions{
+			Name:    name,
+			Buckets: durations.AsDurations(),
+		})}
+	}
+	options := metrics.HistogramOptions{Name: name}
+	if buckets != nil && buckets.Len() > 0 {
+		options.Buckets = buckets.AsValues()
+	}
+	return &scopeHistogram{histogram: s.factory.Histogram(options)}
+}
+
+func (s *scope) Tagged(tags map[string]string) tally.Scope {
+	return &scope{factory: s.factory.Namespace(metrics.NSOptions{Tags: tags})}
+}
+
+func (s *scope) SubScope(name string) tally.Scope {
+	return &scope{factory: s.factory.Namespace(metrics.NSOptions{Name: name})}
+}
+
+func (s *scope) Capabilities() tally.Capabilities {
+	return s
+}
+
+// Reporting implements tally.Capabilities.
+func (s *scope) Reporting() bool {
+	return true
+}
+
+// Tagging implements tally.Capabilities.
+func (s *scope) Tagging() bool {
+	return true
+}
+
+type scopeGauge struct {
+	gauge metrics.Gauge
+}
+
+func (g *scopeGauge) Update(value float64) {
+	g.gauge.Update(int64(value))
+}
+
+type scopeTimer struct {
+	timer metrics.Timer
+}
+
+func (t *scopeTimer) Record(value time.Duration) {
+	t.timer.Record(value)
+}
+
+func (t *scopeTimer) Start() tally.Stopwatch {
+	return tally.NewStopwatch(time.Now(), t)
+}
+
+func (t *scopeTimer) RecordStopwatch(stopwatchStart time.Time) {
+	t.timer.Record(time.Since(stopwatchStart))
+}
+
+// scopeHistogram records into either a Timer, for duration buckets, or a Histogram.
+type scopeHistogram struct {
+	timer     metrics.Timer
+	histogram metrics.Histogram
+}
+
+func (h *scopeHistogram) RecordValue(value float64) {
+	if h.timer != nil {
+		h.timer.Record(time.Duration(value * float64(time.Second)))
+		return
+	}
+	h.histogram.Record(value)
+}
+
+func (h *scopeHistogram) RecordDuration(value time.Duration) {
+	if h.timer != nil {
+		h.timer.Record(value)
+		return
+	}
+	h.histogram.Record(value.Seconds())
+}
+
+func (h *scopeHistogram) Start() tally.Stopwatch {
+	return tally.NewStopwatch(time.Now(), h)
+}
+
+func (h *scopeHistogram) RecordStopwatch(stopwatchStart time.Time) {
+	h.RecordDuration(time.Since(stopwatchStart))
+}
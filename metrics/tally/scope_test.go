@@ -0,0 +1,80 @@
+// Copyright (c) 2026 The Jaeger Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tally
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+
+	"github.com/uber/jaeger-lib/metrics/metricstest"
+)
+
+func TestScope(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	scope := NewScope(f).SubScope("ns").Tagged(map[string]string{"x": "y"})
+	assert.True(t, scope.Capabilities().Tagging())
+	assert.True(t, scope.Capabilities().Reporting())
+
+	scope.Counter("counter").Inc(3)
+	scope.Gauge("gauge").Update(42.7)
+	scope.Timer("timer").Record(time.Second)
+	scope.Timer("timer").Start().Stop()
+	scope.Histogram("histogram", tally.ValueBuckets{1, 10}).RecordValue(5)
+	scope.Histogram("durations", tally.DurationBuckets{time.Second}).RecordDuration(2 * time.Second)
+
+	tags := map[string]string{"x": "y"}
+	f.AssertCounterMetrics(t, metricstest.ExpectedMetric{Name: "ns.counter", Tags: tags, Value: 3})
+	f.AssertGaugeMetrics(t, metricstest.ExpectedMetric{Name: "ns.gauge", Tags: tags, Value: 42})
+
+	// metricstest reports timers in milliseconds, with one significant digit
+	_, gauges := f.Snapshot()
+	assert.InEpsilon(t, 1000, gauges["ns.timer|x=y.P99"], 0.1)
+	assert.EqualValues(t, 5, gauges["ns.histogram|x=y.P50"])
+	assert.InEpsilon(t, 2000, gauges["ns.durations|x=y.P50"], 0.1)
+}
+
+// TestScopeRoundTrip wraps a scope reporting into a tally test scope.
+func TestScopeRoundTrip(t *testing.T) {
+	testScope := tally.NewTestScope("", nil)
+	scope := NewScope(Wrap(testScope))
+
+	scope.Histogram("durations", tally.DurationBuckets{time.Second}).RecordValue(0.5)
+	scope.Histogram("values", tally.ValueBuckets{1, 10}).RecordDuration(2 * time.Second)
+	scope.Histogram("default", tally.DefaultBuckets).RecordValue(0.5)
+
+	snapshot := testScope.Snapshot()
+	durations := histogram(t, snapshot, "durations")
+	assert.EqualValues(t, 1, durations.Durations()[time.Second])
+	values := histogram(t, snapshot, "values")
+	assert.EqualValues(t, 1, values.Values()[10])
+	require.NotNil(t, histogram(t, snapshot, "default"))
+}
+
+func TestScopeHistogramStopwatch(t *testing.T) {
+	f := metricstest.NewFactory(0)
+	defer f.Stop()
+	scope := NewScope(f)
+	scope.Histogram("durations", tally.DurationBuckets{time.Second}).Start().Stop()
+	scope.Histogram("values", nil).Start().Stop()
+
+	_, gauges := f.Snapshot()
+	assert.Contains(t, gauges, "durations.P50")
+	assert.Contains(t, gauges, "values.P50")
+}